@@ -0,0 +1,57 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Describe generates the descriptions of the resources, functions, components and config
+// of an inferred provider from their Go doc comments, so they don't need to be repeated
+// with Annotator.Describe.
+//
+// It is meant to be run by go generate, from a file in the package of the provider:
+//
+//	//go:generate go run github.com/pulumi/pulumi-go-provider/cmd/describe
+//
+// Describe writes a file, descriptions.gen.go by default, that registers the descriptions
+// with infer.RegisterDescriptions. The file must be regenerated when doc comments change.
+//
+// Usage:
+//
+//	describe [-dir dir] [-o file]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pulumi/pulumi-go-provider/internal/describe"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "the directory of the provider package")
+	out := flag.String("o", "descriptions.gen.go", "the file to write, relative to dir if not absolute")
+	flag.Parse()
+
+	path := *out
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(*dir, path)
+	}
+	src, err := describe.Generate(*dir)
+	if err == nil {
+		err = os.WriteFile(path, src, 0o644) //nolint:gosec // Source files are readable.
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "describe: %v\n", err)
+		os.Exit(1)
+	}
+}
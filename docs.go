@@ -0,0 +1,48 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/pulumi/pulumi-go-provider/docs"
+)
+
+// docsCommand is the subcommand of a provider binary that writes its reference documentation.
+const docsCommand = "docs"
+
+// runDocs renders the reference documentation of provider into a directory, as described
+// by args:
+//
+//	docs [-format markdown|html] [-out dir]
+func runDocs(ctx context.Context, name, version string, provider Provider, args []string) error {
+	flags := flag.NewFlagSet(name+" "+docsCommand, flag.ContinueOnError)
+	format := flags.String("format", string(docs.Markdown), "the format of the pages: markdown or html")
+	out := flags.String("out", "docs", "the directory to write the pages to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+
+	spec, err := GetSchema(ctx, name, version, provider.WithDefaults())
+	if err != nil {
+		return fmt.Errorf("unable to get schema: %w", err)
+	}
+	return docs.Write(*out, spec, docs.Format(*format))
+}
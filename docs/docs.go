@@ -0,0 +1,474 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package docs renders reference documentation for a provider from its schema.
+//
+// The schema is usually the one built by the schema middleware, so the pages describe
+// everything that infer collects from the types and annotations of a provider: inputs and
+// outputs, object types and enums, descriptions and examples, defaults, deprecations,
+// secrets and properties that replace a resource when they change.
+//
+// Providers run with [github.com/pulumi/pulumi-go-provider.RunProvider] render their
+// documentation with the docs subcommand of the provider binary:
+//
+//	./pulumi-resource-mypkg docs -format html -out ./reference
+package docs
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// Format is the format that pages are rendered in.
+type Format string
+
+const (
+	// Markdown renders pages as CommonMark.
+	Markdown Format = "markdown"
+	// HTML renders pages as standalone HTML documents.
+	HTML Format = "html"
+)
+
+func (f Format) ext() string {
+	if f == HTML {
+		return ".html"
+	}
+	return ".md"
+}
+
+// A Page is a rendered reference page.
+type Page struct {
+	// The path of the page, relative to the root of the documentation, such as
+	// "resources/storage/bucket.md". Pages link to each other by relative paths.
+	Path string
+	// The rendered page.
+	Content []byte
+}
+
+// Render renders the reference pages of the package described by spec.
+//
+// There is an index page, which describes the package and its configuration and links to
+// the other pages, a page for each resource and a page for each function. The object
+// types and enums used by a resource or a function are described on its page.
+func Render(spec schema.PackageSpec, format Format) ([]Page, error) {
+	var render func(pageData) ([]byte, error)
+	switch format {
+	case Markdown:
+		render = renderMarkdown
+	case HTML:
+		render = renderHTML
+	default:
+		return nil, fmt.Errorf("unknown format %q: expected %q or %q", format, Markdown, HTML)
+	}
+
+	b := builder{spec: spec, format: format}
+	pages := []pageData{b.index()}
+	for _, tk := range slices.Sorted(maps.Keys(spec.Resources)) {
+		pages = append(pages, b.resource(tk, spec.Resources[tk]))
+	}
+	for _, tk := range slices.Sorted(maps.Keys(spec.Functions)) {
+		pages = append(pages, b.function(tk, spec.Functions[tk]))
+	}
+
+	rendered := make([]Page, len(pages))
+	for i, page := range pages {
+		content, err := render(page)
+		if err != nil {
+			return nil, fmt.Errorf("rendering %s: %w", page.Path, err)
+		}
+		rendered[i] = Page{Path: page.Path, Content: content}
+	}
+	return rendered, nil
+}
+
+// Write renders the reference pages of the package described by spec into dir, as
+// [Render] does.
+func Write(dir string, spec schema.PackageSpec, format Format) error {
+	pages, err := Render(spec, format)
+	if err != nil {
+		return err
+	}
+	for _, page := range pages {
+		p := filepath.Join(dir, filepath.FromSlash(page.Path))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil { //nolint:gosec // Docs are readable.
+			return err
+		}
+		if err := os.WriteFile(p, page.Content, 0o644); err != nil { //nolint:gosec // Docs are readable.
+			return err
+		}
+	}
+	return nil
+}
+
+// pageData is the content of a page, independent of its format.
+type pageData struct {
+	Path        string
+	Kind        string // "Package", "Resource", "Component" or "Function"
+	Title       string
+	Token       string
+	Description string
+	Deprecation string
+	Links       []link // Links to the other pages, from the index.
+	Sections    []section
+}
+
+type link struct {
+	Kind, Text, Href string
+}
+
+// A section lists properties, or the values of an enum.
+type section struct {
+	Title       string
+	Anchor      string
+	Description string
+	Properties  []property
+	Values      []enumValue
+	Level       int // The heading level.
+}
+
+type property struct {
+	Name        string
+	Type        []typePart
+	Description string
+	Deprecation string
+	Markers     []string
+	Default     string
+	Environment []string
+
+	refs []string // The references in the type of the property.
+}
+
+// typePart is a part of the description of a type, which may link to the page or section
+// that describes it.
+type typePart struct {
+	Text, Href string
+}
+
+type enumValue struct {
+	Name        string
+	Value       string
+	Description string
+	Deprecation string
+}
+
+type builder struct {
+	spec   schema.PackageSpec
+	format Format
+}
+
+func (b builder) pagePath(kind, tk string) string {
+	t := tokens.Type(tk)
+	mod := t.Module().Name().String()
+	if mod == "" {
+		mod = "index"
+	}
+	return path.Join(kind, mod, strings.ToLower(t.Name().String())) + b.format.ext()
+}
+
+func (b builder) index() pageData {
+	page := pageData{
+		Path:        "index" + b.format.ext(),
+		Kind:        "Package",
+		Title:       b.spec.DisplayName,
+		Token:       b.spec.Name,
+		Description: b.spec.Description,
+	}
+	if page.Title == "" {
+		page.Title = b.spec.Name
+	}
+	for _, tk := range slices.Sorted(maps.Keys(b.spec.Resources)) {
+		kind := "Resources"
+		if b.spec.Resources[tk].IsComponent {
+			kind = "Components"
+		}
+		page.Links = append(page.Links, link{Kind: kind, Text: tk, Href: b.pagePath("resources", tk)})
+	}
+	for _, tk := range slices.Sorted(maps.Keys(b.spec.Functions)) {
+		page.Links = append(page.Links, link{Kind: "Functions", Text: tk, Href: b.pagePath("functions", tk)})
+	}
+	// Components come after resources.
+	slices.SortStableFunc(page.Links, func(a, b link) int {
+		order := []string{"Resources", "Components", "Functions"}
+		return slices.Index(order, a.Kind) - slices.Index(order, b.Kind)
+	})
+
+	if len(b.spec.Config.Variables) > 0 {
+		page.Sections = b.withTypes(page.Path, section{
+			Title:      "Configuration",
+			Anchor:     "configuration",
+			Properties: b.properties(page.Path, b.spec.Config.Variables, b.spec.Config.Required),
+			Level:      2,
+		})
+	}
+	return page
+}
+
+func (b builder) resource(tk string, r schema.ResourceSpec) pageData {
+	page := pageData{
+		Path:        b.pagePath("resources", tk),
+		Kind:        "Resource",
+		Title:       tokens.Type(tk).Name().String(),
+		Token:       tk,
+		Description: r.Description,
+		Deprecation: r.DeprecationMessage,
+	}
+	if r.IsComponent {
+		page.Kind = "Component"
+	}
+	return b.withSections(page,
+		section{
+			Title:      "Inputs",
+			Anchor:     "inputs",
+			Properties: b.properties(page.Path, r.InputProperties, r.RequiredInputs),
+			Level:      2,
+		},
+		section{
+			Title:      "Outputs",
+			Anchor:     "outputs",
+			Properties: b.properties(page.Path, r.Properties, r.Required),
+			Level:      2,
+		},
+	)
+}
+
+func (b builder) function(tk string, f schema.FunctionSpec) pageData {
+	page := pageData{
+		Path:        b.pagePath("functions", tk),
+		Kind:        "Function",
+		Title:       tokens.Type(tk).Name().String(),
+		Token:       tk,
+		Description: f.Description,
+		Deprecation: f.DeprecationMessage,
+	}
+	inputs := section{Title: "Inputs", Anchor: "inputs", Level: 2}
+	if f.Inputs != nil {
+		inputs.Properties = b.properties(page.Path, f.Inputs.Properties, f.Inputs.Required)
+	}
+	outputs := section{Title: "Outputs", Anchor: "outputs", Level: 2}
+	switch {
+	case f.ReturnType != nil && f.ReturnType.ObjectTypeSpec != nil:
+		o := f.ReturnType.ObjectTypeSpec
+		outputs.Properties = b.properties(page.Path, o.Properties, o.Required)
+	case f.ReturnType != nil && f.ReturnType.TypeSpec != nil:
+		outputs.Properties = []property{{Name: "result", Type: b.typeParts(page.Path, *f.ReturnType.TypeSpec)}}
+	case f.Outputs != nil:
+		outputs.Properties = b.properties(page.Path, f.Outputs.Properties, f.Outputs.Required)
+	}
+	return b.withSections(page, inputs, outputs)
+}
+
+func (b builder) withSections(page pageData, sections ...section) pageData {
+	page.Sections = b.withTypes(page.Path, sections...)
+	return page
+}
+
+// withTypes returns sections followed by sections that describe the types they use.
+func (b builder) withTypes(from string, sections ...section) []section {
+	seen := map[string]bool{}
+	var queue []string
+	visit := func(t schema.TypeSpec) {
+		walkType(t, func(ref string) {
+			if tk, ok := localRef(ref, "types"); ok && !seen[tk] {
+				if _, ok := b.spec.Types[tk]; ok {
+					seen[tk] = true
+					queue = append(queue, tk)
+				}
+			}
+		})
+	}
+	collect := func(props map[string]schema.PropertySpec) {
+		for _, name := range slices.Sorted(maps.Keys(props)) {
+			visit(props[name].TypeSpec)
+		}
+	}
+
+	for _, s := range sections {
+		for _, p := range s.Properties {
+			for _, ref := range p.refs {
+				visit(schema.TypeSpec{Ref: ref})
+			}
+		}
+	}
+	var types []string
+	for len(queue) > 0 {
+		tk := queue[0]
+		queue = queue[1:]
+		types = append(types, tk)
+		collect(b.spec.Types[tk].Properties)
+	}
+	slices.Sort(types)
+
+	for _, tk := range types {
+		t := b.spec.Types[tk]
+		s := section{
+			Title:       tokens.Type(tk).Name().String(),
+			Anchor:      anchor(tk),
+			Description: t.Description,
+			Level:       3,
+		}
+		if len(t.Enum) > 0 {
+			for _, v := range t.Enum {
+				s.Values = append(s.Values, enumValue{
+					Name:        v.Name,
+					Value:       fmt.Sprintf("%v", v.Value),
+					Description: v.Description,
+					Deprecation: v.DeprecationMessage,
+				})
+			}
+		} else {
+			s.Properties = b.properties(from, t.Properties, t.Required)
+		}
+		sections = append(sections, s)
+	}
+	return sections
+}
+
+func (b builder) properties(from string, props map[string]schema.PropertySpec, required []string) []property {
+	var list []property
+	for _, name := range slices.Sorted(maps.Keys(props)) {
+		p := props[name]
+		prop := property{
+			Name:        name,
+			Type:        b.typeParts(from, p.TypeSpec),
+			Description: p.Description,
+			Deprecation: p.DeprecationMessage,
+		}
+		walkType(p.TypeSpec, func(ref string) { prop.refs = append(prop.refs, ref) })
+		if slices.Contains(required, name) {
+			prop.Markers = append(prop.Markers, "required")
+		} else {
+			prop.Markers = append(prop.Markers, "optional")
+		}
+		if p.Secret {
+			prop.Markers = append(prop.Markers, "secret")
+		}
+		if p.ReplaceOnChanges {
+			prop.Markers = append(prop.Markers, "replaces on change")
+		}
+		if p.DeprecationMessage != "" {
+			prop.Markers = append(prop.Markers, "deprecated")
+		}
+		if p.Default != nil {
+			prop.Default = fmt.Sprintf("%v", p.Default)
+		}
+		if p.DefaultInfo != nil {
+			prop.Environment = p.DefaultInfo.Environment
+		}
+		list = append(list, prop)
+	}
+	return list
+}
+
+// typeParts describes t, linking the types it refers to.
+func (b builder) typeParts(from string, t schema.TypeSpec) []typePart {
+	switch {
+	case t.Ref != "":
+		return []typePart{b.refPart(from, t.Ref)}
+	case len(t.OneOf) > 0:
+		var parts []typePart
+		for i, variant := range t.OneOf {
+			if i > 0 {
+				parts = append(parts, typePart{Text: " | "})
+			}
+			parts = append(parts, b.typeParts(from, variant)...)
+		}
+		return parts
+	case t.Type == "array" && t.Items != nil:
+		return wrapParts("List<", b.typeParts(from, *t.Items), ">")
+	case t.Type == "object" && t.AdditionalProperties != nil:
+		return wrapParts("Map<", b.typeParts(from, *t.AdditionalProperties), ">")
+	case t.Type == "":
+		return []typePart{{Text: "any"}}
+	}
+	return []typePart{{Text: t.Type}}
+}
+
+func wrapParts(prefix string, parts []typePart, suffix string) []typePart {
+	return append(append([]typePart{{Text: prefix}}, parts...), typePart{Text: suffix})
+}
+
+func (b builder) refPart(from, ref string) typePart {
+	switch ref {
+	case "pulumi.json#/Any":
+		return typePart{Text: "any"}
+	case "pulumi.json#/Json":
+		return typePart{Text: "JSON"}
+	case "pulumi.json#/Asset":
+		return typePart{Text: "Asset"}
+	case "pulumi.json#/Archive":
+		return typePart{Text: "Archive"}
+	}
+	if tk, ok := localRef(ref, "types"); ok {
+		if _, ok := b.spec.Types[tk]; ok {
+			return typePart{Text: tokens.Type(tk).Name().String(), Href: "#" + anchor(tk)}
+		}
+	}
+	if tk, ok := localRef(ref, "resources"); ok {
+		if _, ok := b.spec.Resources[tk]; ok {
+			return typePart{Text: tokens.Type(tk).Name().String(), Href: relative(from, b.pagePath("resources", tk))}
+		}
+	}
+	// A reference to another package.
+	return typePart{Text: ref[strings.LastIndexAny(ref, ":/")+1:]}
+}
+
+// localRef returns the token that ref refers to when ref refers to a member of section
+// in the same schema, such as "#/types/pkg:mod%2Fnested:Type".
+func localRef(ref, section string) (string, bool) {
+	tk, ok := strings.CutPrefix(ref, "#/"+section+"/")
+	if !ok {
+		return "", false
+	}
+	tk, err := url.PathUnescape(tk)
+	return tk, err == nil
+}
+
+// walkType calls f with each reference in t.
+func walkType(t schema.TypeSpec, f func(ref string)) {
+	if t.Ref != "" {
+		f(t.Ref)
+	}
+	if t.Items != nil {
+		walkType(*t.Items, f)
+	}
+	if t.AdditionalProperties != nil {
+		walkType(*t.AdditionalProperties, f)
+	}
+	for _, variant := range t.OneOf {
+		walkType(variant, f)
+	}
+}
+
+// anchor returns the anchor of the section that describes the type tk.
+func anchor(tk string) string {
+	return strings.ToLower(strings.NewReplacer(":", "-", "/", "-").Replace(tk))
+}
+
+// relative returns the link from the page at from to the page at to.
+func relative(from, to string) string {
+	rel, err := filepath.Rel(filepath.FromSlash(path.Dir(from)), filepath.FromSlash(to))
+	if err != nil {
+		return to
+	}
+	return filepath.ToSlash(rel)
+}
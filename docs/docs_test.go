@@ -0,0 +1,239 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docs_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/docs"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type (
+	Bucket     struct{}
+	BucketArgs struct {
+		Name       *string           `pulumi:"name,optional" provider:"replaceOnChanges"`
+		Region     string            `pulumi:"region"`
+		AccessKey  *string           `pulumi:"accessKey,optional" provider:"secret"`
+		Versioning *Versioning       `pulumi:"versioning,optional"`
+		Tags       map[string]string `pulumi:"tags,optional"`
+		Legacy     *bool             `pulumi:"legacy,optional"`
+	}
+	BucketState struct {
+		BucketArgs
+		URL   string `pulumi:"url"`
+		Rules []Rule `pulumi:"rules"`
+	}
+	Versioning struct {
+		Enabled bool `pulumi:"enabled"`
+		Mode    Mode `pulumi:"mode"`
+	}
+	Rule struct {
+		Prefix string `pulumi:"prefix"`
+	}
+	Mode string
+
+	GetBucket     struct{}
+	GetBucketArgs struct {
+		Name string `pulumi:"name"`
+	}
+
+	Config struct {
+		Token string `pulumi:"token" provider:"secret"`
+	}
+)
+
+func (Mode) Values() []infer.EnumValue[Mode] {
+	return []infer.EnumValue[Mode]{
+		{Name: "Suspended", Value: "suspended", Description: "Versioning is suspended."},
+		{Name: "Enabled", Value: "enabled"},
+	}
+}
+
+func (b *Bucket) Annotate(a infer.Annotator) {
+	a.Describe(&b, "A storage bucket.\n\nBuckets hold `objects`.")
+	a.AddExample("A bucket", map[string]string{
+		"yaml": "resources:\n  b:\n    type: storage:index:Bucket\n    properties:\n      region: us-east-1",
+	})
+}
+
+func (args *BucketArgs) Annotate(a infer.Annotator) {
+	a.Describe(&args.Region, "The region of the bucket.")
+	a.SetDefault(&args.Region, "us-east-1", "STORAGE_REGION")
+	a.Deprecate(&args.Legacy, "Use versioning instead.")
+}
+
+func (*Bucket) Create(
+	context.Context, infer.CreateRequest[BucketArgs],
+) (infer.CreateResponse[BucketState], error) {
+	panic("unimplemented")
+}
+
+func (g *GetBucket) Annotate(a infer.Annotator) {
+	a.Describe(&g, "Looks up a bucket.")
+}
+
+func (*GetBucket) Invoke(
+	context.Context, infer.FunctionRequest[GetBucketArgs],
+) (infer.FunctionResponse[BucketState], error) {
+	panic("unimplemented")
+}
+
+func spec(t *testing.T) schema.PackageSpec {
+	provider := infer.NewProviderBuilder().
+		WithDisplayName("Storage").
+		WithDescription("Manages storage.").
+		WithResources(infer.Resource(&Bucket{})).
+		WithFunctions(infer.Function(&GetBucket{})).
+		WithConfig(infer.Config(&Config{})).
+		WithModuleMap(map[tokens.ModuleName]tokens.ModuleName{"docs_test": "index"}).
+		BuildOptions()
+	spec, err := p.GetSchema(t.Context(), "storage", "1.0.0", infer.Provider(provider))
+	require.NoError(t, err)
+	return spec
+}
+
+func render(t *testing.T, spec schema.PackageSpec, format docs.Format) map[string]string {
+	pages, err := docs.Render(spec, format)
+	require.NoError(t, err)
+	rendered := map[string]string{}
+	for _, page := range pages {
+		rendered[page.Path] = string(page.Content)
+	}
+	return rendered
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	t.Parallel()
+	pages := render(t, spec(t), docs.Markdown)
+	require.Len(t, pages, 3)
+
+	assert.Equal(t, "# Storage\n\nPackage `storage`\n\nManages storage.\n\n"+
+		"## Resources\n\n- [storage:index:Bucket](resources/index/bucket.md)\n\n"+
+		"## Functions\n\n- [storage:index:getBucket](functions/index/getbucket.md)\n\n"+
+		"## Configuration\n\n- **`token`** `string` _(required, secret)_\n",
+		pages["index.md"])
+
+	bucket := pages["resources/index/bucket.md"]
+	assert.Contains(t, bucket, "# Bucket\n\nResource `storage:index:Bucket`\n\nA storage bucket.\n")
+	assert.NotContains(t, bucket, "{{%", "shortcodes should be removed")
+	assert.Contains(t, bucket, "## Example Usage\n### A bucket\n\n```yaml\n")
+	assert.Contains(t, bucket, "- **`region`** `string` _(required)_\n\n"+
+		"  The region of the bucket.\n\n"+
+		"  Default: `us-east-1`\n\n"+
+		"  Environment: `STORAGE_REGION`\n")
+	assert.Contains(t, bucket, "- **`accessKey`** `string` _(optional, secret)_\n")
+	assert.Contains(t, bucket, "- **`name`** `string` _(optional, replaces on change)_\n")
+	assert.Contains(t, bucket, "- **`legacy`** `boolean` _(optional, deprecated)_\n\n"+
+		"  **Deprecated:** Use versioning instead.\n")
+	assert.Contains(t, bucket, "- **`tags`** `Map<string>` _(optional)_\n")
+	assert.Contains(t, bucket, "- **`rules`** `List<`[`Rule`](#storage-index-rule)`>` _(required)_\n")
+	assert.Contains(t, bucket, "### Versioning\n\n"+
+		"- **`enabled`** `boolean` _(required)_\n\n"+
+		"- **`mode`** [`Mode`](#storage-index-mode) _(required)_\n")
+	assert.Contains(t, bucket, "### Mode\n\n"+
+		"- `suspended`\n\n  Versioning is suspended.\n\n"+
+		"- `enabled`\n")
+
+	getBucket := pages["functions/index/getbucket.md"]
+	assert.Contains(t, getBucket, "# getBucket\n\nFunction `storage:index:getBucket`\n\nLooks up a bucket.\n")
+	assert.Contains(t, getBucket, "## Inputs\n\n- **`name`** `string` _(required)_\n\n## Outputs\n")
+	assert.Contains(t, getBucket, "### Rule\n")
+}
+
+func TestRenderHTML(t *testing.T) {
+	t.Parallel()
+	pages := render(t, spec(t), docs.HTML)
+	require.Len(t, pages, 3)
+
+	assert.Contains(t, pages["index.html"],
+		`<li><a href="resources/index/bucket.html">storage:index:Bucket</a></li>`)
+
+	bucket := pages["resources/index/bucket.html"]
+	assert.Contains(t, bucket, "<title>Bucket</title>")
+	assert.Contains(t, bucket, "<p>Buckets hold <code>objects</code>.</p>")
+	assert.Contains(t, bucket, `<pre><code class="language-yaml">resources:`)
+	assert.Contains(t, bucket, `<h2 id="inputs">Inputs</h2>`)
+	assert.Contains(t, bucket, `<h3 id="storage-index-versioning">Versioning</h3>`)
+	assert.Contains(t, bucket, "<dt><code>rules</code> "+
+		`<code>List&lt;<a href="#storage-index-rule">Rule</a>&gt;</code> <em>(required)</em></dt>`)
+	assert.Contains(t, bucket, "<p>Environment: <code>STORAGE_REGION</code></p>")
+	assert.Contains(t, bucket, "<dt><code>suspended</code></dt>\n<dd><p>Versioning is suspended.</p>")
+	assert.NotContains(t, bucket, "{{%")
+}
+
+func TestRenderReferences(t *testing.T) {
+	t.Parallel()
+	spec := schema.PackageSpec{
+		Name: "pkg",
+		Resources: map[string]schema.ResourceSpec{
+			"pkg:index:Parent": {
+				IsComponent:        true,
+				DeprecationMessage: "Use Child instead.",
+				InputProperties: map[string]schema.PropertySpec{
+					"child": {TypeSpec: schema.TypeSpec{Ref: "#/resources/pkg:nested%2Fmod:Child"}},
+					"value": {TypeSpec: schema.TypeSpec{OneOf: []schema.TypeSpec{
+						{Type: "string"},
+						{Ref: "pulumi.json#/Any"},
+					}}},
+					"external": {TypeSpec: schema.TypeSpec{Ref: "/aws/v6.0.0/schema.json#/resources/aws:s3:Bucket"}},
+				},
+			},
+			"pkg:nested/mod:Child": {},
+		},
+	}
+	pages := render(t, spec, docs.Markdown)
+
+	assert.Contains(t, pages["index.md"], "## Resources\n\n- [pkg:nested/mod:Child](resources/nested/mod/child.md)\n\n"+
+		"## Components\n\n- [pkg:index:Parent](resources/index/parent.md)\n")
+
+	parent := pages["resources/index/parent.md"]
+	assert.Contains(t, parent, "Component `pkg:index:Parent`\n\n> **Deprecated:** Use Child instead.\n")
+	assert.Contains(t, parent, "- **`child`** [`Child`](../nested/mod/child.md) _(optional)_\n")
+	assert.Contains(t, parent, "- **`value`** `string | any` _(optional)_\n")
+	assert.Contains(t, parent, "- **`external`** `Bucket` _(optional)_\n")
+	assert.Contains(t, parent, "## Outputs\n\nNone.\n")
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	t.Parallel()
+	_, err := docs.Render(schema.PackageSpec{Name: "pkg"}, "pdf")
+	assert.ErrorContains(t, err, "pdf")
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, docs.Write(dir, spec(t), docs.HTML))
+
+	for _, path := range []string{
+		"index.html",
+		"resources/index/bucket.html",
+		"functions/index/getbucket.html",
+	} {
+		b, err := os.ReadFile(filepath.Join(dir, path))
+		require.NoError(t, err)
+		assert.Contains(t, string(b), "<!DOCTYPE html>")
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// shortcodes matches the lines of the shortcodes that the Pulumi registry uses to lay out
+// examples, which mean nothing outside of it.
+var shortcodes = regexp.MustCompile(`(?m)^{{% /?examples? %}}\n?`)
+
+// description returns a description from the schema as Markdown.
+func description(s string) string {
+	return strings.TrimSpace(shortcodes.ReplaceAllString(s, ""))
+}
+
+var markdownTemplate = template.Must(template.New("page").Funcs(template.FuncMap{
+	"description": description,
+	"indent": func(s string) string {
+		return strings.ReplaceAll(s, "\n", "\n  ")
+	},
+	"type": func(parts []typePart) string {
+		var b strings.Builder
+		for _, p := range parts {
+			if p.Href != "" {
+				fmt.Fprintf(&b, "[`%s`](%s)", p.Text, p.Href)
+			} else {
+				fmt.Fprintf(&b, "`%s`", p.Text)
+			}
+		}
+		// Adjacent code spans are merged, such as `List<``string``>` into `List<string>`.
+		return strings.ReplaceAll(b.String(), "``", "")
+	},
+	"heading": func(level int) string { return strings.Repeat("#", level) },
+	"join":    strings.Join,
+}).Parse(`# {{.Title}}
+
+{{.Kind}} ` + "`{{.Token}}`" + `
+{{- if .Deprecation}}
+
+> **Deprecated:** {{.Deprecation}}
+{{- end}}
+{{- with description .Description}}
+
+{{.}}
+{{- end}}
+{{- $kind := ""}}
+{{- range .Links}}
+{{- if ne .Kind $kind}}{{$kind = .Kind}}
+
+## {{.Kind}}
+{{end}}
+- [{{.Text}}]({{.Href}})
+{{- end}}
+{{- range .Sections}}
+
+{{heading .Level}} {{.Title}}
+{{- with description .Description}}
+
+{{.}}
+{{- end}}
+{{- if and (not .Properties) (not .Values)}}
+
+None.
+{{- end}}
+{{- range .Properties}}
+
+- ` + "**`{{.Name}}`**" + ` {{type .Type}} _({{join .Markers ", "}})_
+{{- with description .Description}}
+
+  {{indent .}}
+{{- end}}
+{{- if .Deprecation}}
+
+  **Deprecated:** {{indent .Deprecation}}
+{{- end}}
+{{- if .Default}}
+
+  Default: ` + "`{{.Default}}`" + `
+{{- end}}
+{{- if .Environment}}
+
+  Environment: {{range $i, $e := .Environment}}{{if $i}}, {{end}}` + "`{{$e}}`" + `{{end}}
+{{- end}}
+{{- end}}
+{{- range .Values}}
+
+- {{if .Name}}**{{.Name}}** {{end}}` + "`{{.Value}}`" + `
+{{- with description .Description}}
+
+  {{indent .}}
+{{- end}}
+{{- if .Deprecation}}
+
+  **Deprecated:** {{indent .Deprecation}}
+{{- end}}
+{{- end}}
+{{- end}}
+`))
+
+func renderMarkdown(page pageData) ([]byte, error) {
+	var b bytes.Buffer
+	err := markdownTemplate.Execute(&b, page)
+	return b.Bytes(), err
+}
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("page").Funcs(htmltemplate.FuncMap{
+	"description": descriptionHTML,
+	"join":        strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.Kind}} <code>{{.Token}}</code></p>
+{{- if .Deprecation}}
+<p><strong>Deprecated:</strong> {{.Deprecation}}</p>
+{{- end}}
+{{- with .Description}}
+{{description .}}
+{{- end}}
+{{- $kind := ""}}
+{{- range $i, $l := .Links}}
+{{- if ne .Kind $kind}}{{if $i}}
+</ul>{{end}}{{$kind = .Kind}}
+<h2>{{.Kind}}</h2>
+<ul>{{end}}
+<li><a href="{{.Href}}">{{.Text}}</a></li>
+{{- end}}
+{{- if .Links}}
+</ul>
+{{- end}}
+{{- range .Sections}}
+{{- if eq .Level 2}}
+<h2 id="{{.Anchor}}">{{.Title}}</h2>
+{{- else}}
+<h3 id="{{.Anchor}}">{{.Title}}</h3>
+{{- end}}
+{{- with .Description}}
+{{description .}}
+{{- end}}
+{{- if and (not .Properties) (not .Values)}}
+<p>None.</p>
+{{- end}}
+{{- if .Properties}}
+<dl>
+{{- range .Properties}}
+<dt><code>{{.Name}}</code> <code>
+{{- range .Type}}{{if .Href}}<a href="{{.Href}}">{{.Text}}</a>{{else}}{{.Text}}{{end}}{{end -}}
+</code> <em>({{join .Markers ", "}})</em></dt>
+<dd>
+{{- description .Description}}
+{{- if .Deprecation}}
+<p><strong>Deprecated:</strong> {{.Deprecation}}</p>
+{{- end}}
+{{- if .Default}}
+<p>Default: <code>{{.Default}}</code></p>
+{{- end}}
+{{- if .Environment}}
+<p>Environment: {{range $i, $e := .Environment}}{{if $i}}, {{end}}<code>{{$e}}</code>{{end}}</p>
+{{- end}}
+</dd>
+{{- end}}
+</dl>
+{{- end}}
+{{- if .Values}}
+<dl>
+{{- range .Values}}
+<dt>{{if .Name}}<strong>{{.Name}}</strong> {{end}}<code>{{.Value}}</code></dt>
+<dd>
+{{- description .Description}}
+{{- if .Deprecation}}
+<p><strong>Deprecated:</strong> {{.Deprecation}}</p>
+{{- end}}
+</dd>
+{{- end}}
+</dl>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+func renderHTML(page pageData) ([]byte, error) {
+	var b bytes.Buffer
+	err := htmlTemplate.Execute(&b, page)
+	return b.Bytes(), err
+}
+
+var (
+	fence      = regexp.MustCompile("^```(\\S*)\\s*$")
+	headingRe  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	codeSpanRe = regexp.MustCompile("`([^`]+)`")
+)
+
+// descriptionHTML converts a description from the schema to HTML.
+//
+// Only the Markdown that descriptions usually hold is converted: paragraphs, headings,
+// fenced code blocks and code spans. Everything else is kept as text.
+func descriptionHTML(s string) htmltemplate.HTML {
+	var b strings.Builder
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			text := htmltemplate.HTMLEscapeString(strings.Join(paragraph, "\n"))
+			fmt.Fprintf(&b, "<p>%s</p>\n", codeSpanRe.ReplaceAllString(text, "<code>$1</code>"))
+			paragraph = nil
+		}
+	}
+
+	lines := strings.Split(description(s), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if m := fence.FindStringSubmatch(line); m != nil {
+			flush()
+			var code []string
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "```"; i++ {
+				code = append(code, lines[i])
+			}
+			class := ""
+			if m[1] != "" {
+				class = fmt.Sprintf(` class="language-%s"`, htmltemplate.HTMLEscapeString(m[1]))
+			}
+			fmt.Fprintf(&b, "<pre><code%s>%s</code></pre>\n",
+				class, htmltemplate.HTMLEscapeString(strings.Join(code, "\n")))
+			continue
+		}
+		if m := headingRe.FindStringSubmatch(line); m != nil {
+			flush()
+			// Headings in descriptions are nested under the heading of the page.
+			level := min(len(m[1])+1, 6)
+			fmt.Fprintf(&b, "<h%d>%s</h%d>\n", level, htmltemplate.HTMLEscapeString(m[2]), level)
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		paragraph = append(paragraph, line)
+	}
+	flush()
+	//nolint:gosec // Everything from the description is escaped.
+	return htmltemplate.HTML(strings.TrimSuffix(b.String(), "\n"))
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 
+	"github.com/pulumi/pulumi-go-provider/infer/internal/ende"
 	"github.com/pulumi/pulumi-go-provider/internal/introspect"
 )
 
@@ -293,6 +294,11 @@ func setDefaultFromMemory(field reflect.Value, value any) error {
 	field.Set(hydratedValue(field))
 	field = derefNonNil(field)
 
+	// Defaults for a value wrapper apply to the value it holds.
+	if _, ok := ende.Unwrap(field.Type()); ok {
+		return setDefaultFromMemory(field.Field(0), value)
+	}
+
 	v := reflect.ValueOf(value)
 	if v.CanConvert(field.Type()) {
 		field.Set(v.Convert(field.Type()))
@@ -311,22 +317,34 @@ func setDefaultValueFromEnv(field reflect.Value, value string) error {
 	for typ.Kind() == reflect.Pointer {
 		typ = typ.Elem()
 	}
+	if inner, ok := ende.Unwrap(typ); ok {
+		typ = inner
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+	}
 
 	switch typ.Kind() {
 	case reflect.String:
 		return setDefaultFromMemory(field, value)
-	case reflect.Float64:
-		f, err := strconv.ParseFloat(value, 64)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, typ.Bits())
 		if err != nil {
 			return err
 		}
 		return setDefaultFromMemory(field, f)
-	case reflect.Int:
-		i, err := strconv.ParseInt(value, 0, 64)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 0, typ.Bits())
 		if err != nil {
 			return err
 		}
 		return setDefaultFromMemory(field, i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 0, typ.Bits())
+		if err != nil {
+			return err
+		}
+		return setDefaultFromMemory(field, u)
 	case reflect.Bool:
 		b, err := strconv.ParseBool(value)
 		if err != nil {
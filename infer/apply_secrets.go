@@ -22,6 +22,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v3/go/property"
 
+	"github.com/pulumi/pulumi-go-provider/infer/internal/ende"
 	"github.com/pulumi/pulumi-go-provider/internal/introspect"
 	"github.com/pulumi/pulumi-go-provider/internal/putil"
 )
@@ -63,6 +64,21 @@ func (w *secretsWalker) walk(t reflect.Type, p resource.PropertyValue) (out reso
 		t = t.Elem()
 	}
 
+	// Secrets are applied to the value held by a wrapper, not the wrapper itself.
+	if inner, ok := ende.Unwrap(t); ok {
+		return w.walk(inner, p)
+	}
+
+	// The variant of a union is not known here, so the secrets of every variant that
+	// fits p are applied. This may mark more values secret than needed, but never
+	// fewer.
+	if variants, ok := ende.UnionVariants(t); ok {
+		for _, variant := range variants {
+			p = w.walk(variant, p)
+		}
+		return p
+	}
+
 	// Here is where we attempt to apply secrets from type information.
 	//
 	// If the shape of p does not match the type of t, we will simply return
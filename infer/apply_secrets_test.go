@@ -177,6 +177,24 @@ func TestApplySecrets(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name: "wrapped-secrets",
+			typ: reflect.TypeFor[struct {
+				F1 Secret[struct {
+					F1 string `pulumi:"f1" provider:"secret"`
+				}] `pulumi:"f1"`
+			}](),
+			input: resource.NewPropertyMapFromMap(map[string]any{
+				"f1": map[string]any{
+					"f1": "secret1",
+				},
+			}),
+			expected: resource.PropertyMap{
+				"f1": resource.NewProperty(resource.PropertyMap{
+					"f1": resource.MakeSecret(resource.NewProperty("secret1")),
+				}),
+			},
+		},
 		{
 			name: "mismatched-types",
 			typ: reflect.TypeFor[struct {
@@ -0,0 +1,201 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/internal/introspect"
+)
+
+const defaultAutoNamePattern = "${name}-${hex(7)}"
+
+var autoNameExpr = regexp.MustCompile(`\$\{([a-z]+)(?:\((\d+)\))?\}`)
+
+// checkContextKey carries the parts of a resource's Check request that [DefaultCheck]
+// needs to generate names.
+type checkContextKey struct{}
+
+type checkContext struct {
+	urn        resource.URN
+	olds       property.Map
+	randomSeed []byte
+	autonaming *p.AutonamingOptions
+}
+
+func withCheckContext(ctx context.Context, req p.CheckRequest, olds property.Map) context.Context {
+	return context.WithValue(ctx, checkContextKey{}, checkContext{
+		urn:        req.Urn,
+		olds:       olds,
+		randomSeed: req.RandomSeed,
+		autonaming: req.Autonaming,
+	})
+}
+
+// applyAutoNames sets the fields of i that are annotated with [Annotator.SetAutoName]
+// and are not set.
+func applyAutoNames[I any](ctx context.Context, i *I) ([]p.CheckFailure, error) {
+	c, ok := ctx.Value(checkContextKey{}).(checkContext)
+	if !ok {
+		return nil, nil
+	}
+	autoNames := getAnnotated(reflect.TypeFor[I]()).AutoNames
+	if len(autoNames) == 0 {
+		return nil, nil
+	}
+
+	v := reflect.ValueOf(i).Elem()
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	var failures []p.CheckFailure
+	for _, field := range reflect.VisibleFields(v.Type()) {
+		tag, err := introspect.ParseTag(field)
+		if err != nil || tag.Internal {
+			continue
+		}
+		autoName, ok := autoNames[tag.Name]
+		if !ok {
+			continue
+		}
+
+		fv := v.FieldByIndex(field.Index)
+		if (fv.Kind() == reflect.String && fv.String() != "") ||
+			(fv.Kind() == reflect.Pointer && !fv.IsNil()) {
+			continue
+		}
+
+		name, failure, err := c.autoName(tag.Name, autoName)
+		if err != nil {
+			return nil, err
+		}
+		if failure != nil {
+			failures = append(failures, *failure)
+			continue
+		}
+		if fv.Kind() == reflect.Pointer {
+			fv.Set(reflect.ValueOf(&name))
+		} else {
+			fv.SetString(name)
+		}
+	}
+	return failures, nil
+}
+
+// autoName returns the name for the auto-named field.
+func (c checkContext) autoName(field string, autoName introspect.AutoName) (string, *p.CheckFailure, error) {
+	// A name that was generated before is kept, so that updating a resource does not
+	// replace it.
+	if prev := c.olds.Get(field); prev.IsString() && prev.AsString() != "" {
+		return prev.AsString(), nil, nil
+	}
+
+	if a := c.autonaming; a != nil {
+		switch a.Mode {
+		case p.AutonamingModeDisable:
+			return "", &p.CheckFailure{
+				Property: field,
+				Reason:   fmt.Sprintf("%s is required because autonaming is disabled", field),
+			}, nil
+		case p.AutonamingModeEnforce:
+			return a.ProposedName, nil, nil
+		case p.AutonamingModePropose:
+			if autoName.MaxLength <= 0 || len(a.ProposedName) <= autoName.MaxLength {
+				return a.ProposedName, nil, nil
+			}
+		}
+	}
+
+	name, err := expandAutoName(c.urn.Name(), autoName, NewRandom(c.randomSeed, "autoname:"+field))
+	return name, nil, err
+}
+
+// expandAutoName expands the pattern of autoName for the resource called name.
+func expandAutoName(name string, autoName introspect.AutoName, random *Random) (string, error) {
+	pattern := autoName.Pattern
+	if pattern == "" {
+		pattern = defaultAutoNamePattern
+	}
+
+	// Random values are generated once, so that truncating the name does not change
+	// them. A nil value stands for the name.
+	var nameCount int
+	var values []*string
+	for _, m := range autoNameExpr.FindAllStringSubmatch(pattern, -1) {
+		n, _ := strconv.Atoi(m[2])
+		var v string
+		switch m[1] {
+		case "name":
+			nameCount++
+			values = append(values, nil)
+			continue
+		case "hex":
+			v = random.fromChars("0123456789abcdef", n)
+		case "alphanum":
+			v = random.fromChars(suffixChars, n)
+		case "string":
+			v = random.fromChars(suffixChars[:26], n)
+		case "num":
+			v = random.fromChars("0123456789", n)
+		case "uuid":
+			v = random.UUID()
+		default:
+			return "", fmt.Errorf("unknown expression %q in auto-name pattern %q", m[0], pattern)
+		}
+		values = append(values, &v)
+	}
+
+	parts := autoNameExpr.Split(pattern, -1)
+	build := func(name string) string {
+		var b strings.Builder
+		for i, part := range parts {
+			b.WriteString(part)
+			switch {
+			case i >= len(values):
+			case values[i] == nil:
+				b.WriteString(name)
+			default:
+				b.WriteString(*values[i])
+			}
+		}
+		return b.String()
+	}
+
+	result := build(name)
+	if maxLen := autoName.MaxLength; maxLen > 0 && len(result) > maxLen {
+		if nameCount > 0 {
+			overflow := len(result) - maxLen
+			cut := (overflow + nameCount - 1) / nameCount
+			result = build(name[:len(name)-min(cut, len(name))])
+		}
+		if len(result) > maxLen {
+			result = result[:maxLen]
+		}
+	}
+	return result, nil
+}
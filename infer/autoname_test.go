@@ -0,0 +1,60 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi-go-provider/internal/introspect"
+)
+
+func TestExpandAutoName(t *testing.T) {
+	t.Parallel()
+
+	seed := []byte("seed")
+	tests := []struct {
+		name     string
+		autoName introspect.AutoName
+		expected string
+	}{
+		{"default", introspect.AutoName{}, `^bucket-[0-9a-f]{7}$`},
+		{
+			"uuid", introspect.AutoName{Pattern: "${uuid}"},
+			`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
+		},
+		{"mixed", introspect.AutoName{Pattern: "${string(2)}_${name}_${num(3)}"}, `^[a-z]{2}_bucket_[0-9]{3}$`},
+		{"truncated", introspect.AutoName{Pattern: "${name}-${alphanum(4)}", MaxLength: 8}, `^buc-[a-z0-9]{4}$`},
+		{"too-long", introspect.AutoName{Pattern: "prefix-${hex(8)}", MaxLength: 10}, `^prefix-[0-9a-f]{3}$`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := expandAutoName("bucket", tt.autoName, NewRandom(seed, tt.name))
+			require.NoError(t, err)
+			assert.Regexp(t, tt.expected, actual)
+
+			again, err := expandAutoName("bucket", tt.autoName, NewRandom(seed, tt.name))
+			require.NoError(t, err)
+			assert.Equal(t, actual, again)
+		})
+	}
+
+	_, err := expandAutoName("bucket", introspect.AutoName{Pattern: "${name}-${rand}"}, NewRandom(seed, ""))
+	assert.ErrorContains(t, err, `unknown expression "${rand}"`)
+}
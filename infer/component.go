@@ -47,10 +47,16 @@ type InferredComponent interface {
 	schema.Resource
 
 	isInferredComponent()
+	withTokenNamer(TokenNamer) InferredComponent
 }
 
 func (derivedComponentController[R, T, I, O]) isInferredComponent() {}
 
+func (rc derivedComponentController[R, T, I, O]) withTokenNamer(namer TokenNamer) InferredComponent {
+	rc.namer = namer
+	return &rc
+}
+
 // Component defines a component resource from go code. Here `R` is the component resource
 // anchor, `I` describes its inputs and `O` its outputs. To add descriptions to `R`, `I`
 // and `O`, see the `Annotated` trait defined in this module.
@@ -87,19 +93,20 @@ func ComponentF[A any, O pulumi.ComponentResource, F ComponentFn[A, O]](fn F) In
 
 type derivedComponentController[R ComponentResource[I, O], T any, I any, O pulumi.ComponentResource] struct {
 	receiver *R
+	namer    TokenNamer
 }
 
 func (rc *derivedComponentController[R, T, I, O]) GetSchema(reg schema.RegisterDerivativeType) (
 	pschema.ResourceSpec, error,
 ) {
-	r, err := getResourceSchema[T, I, O](true)
+	r, err := getResourceSchema[T, I, O](true, rc.namer)
 	if err := err.ErrorOrNil(); err != nil {
 		return pschema.ResourceSpec{}, err
 	}
-	if err := registerTypes[I](reg); err != nil {
+	if err := registerTypes[I](reg, rc.namer); err != nil {
 		return pschema.ResourceSpec{}, err
 	}
-	if err := registerTypes[O](reg); err != nil {
+	if err := registerTypes[O](reg, rc.namer); err != nil {
 		return pschema.ResourceSpec{}, err
 	}
 	return r, nil
@@ -113,7 +120,7 @@ func (rc *derivedComponentController[R, T, I, O]) GetToken() (tokens.Type, error
 			return tokens.Type(a.Token), nil
 		}
 	}
-	return getToken[T](nil)
+	return getToken[T](rc.namer, nil)
 }
 
 // Construct implements InferredComponent.
@@ -45,6 +45,8 @@ type InferredConfig interface {
 	checkConfig(ctx context.Context, req p.CheckRequest) (p.CheckResponse, error)
 	diffConfig(ctx context.Context, req p.DiffRequest) (p.DiffResponse, error)
 	configure(ctx context.Context, req p.ConfigureRequest) error
+	retryPolicy() (RetryPolicy, bool)
+	withTokenNamer(TokenNamer) InferredConfig
 }
 
 // CustomConfigure describes a provider that requires custom configuration before running.
@@ -63,7 +65,15 @@ type CustomConfigure interface {
 	Configure(ctx context.Context) error
 }
 
-type config[T any] struct{ receiver *T }
+type config[T any] struct {
+	receiver *T
+	namer    TokenNamer
+}
+
+func (c config[T]) withTokenNamer(namer TokenNamer) InferredConfig {
+	c.namer = namer
+	return &c
+}
 
 func (*config[T]) underlyingType() reflect.Type {
 	var t T
@@ -72,10 +82,10 @@ func (*config[T]) underlyingType() reflect.Type {
 
 func (*config[T]) GetToken() (tokens.Type, error) { return "pulumi:providers:pkg", nil }
 func (c *config[T]) GetSchema(reg schema.RegisterDerivativeType) (pschema.ResourceSpec, error) {
-	if err := registerTypes[T](reg); err != nil {
+	if err := registerTypes[T](reg, c.namer); err != nil {
 		return pschema.ResourceSpec{}, err
 	}
-	r, errs := getResourceSchema[T, T, T](false)
+	r, errs := getResourceSchema[T, T, T](false, c.namer)
 	return r, errs.ErrorOrNil()
 }
 
@@ -102,7 +112,7 @@ func (c *config[T]) checkConfig(ctx context.Context, req p.CheckRequest) (p.Chec
 		if req.Urn != "" {
 			name = req.Urn.Name()
 		}
-		defCheckEnc, i, failures, err := callCustomCheck(ctx, t, name, req.State, req.Inputs)
+		defCheckEnc, i, failures, err := callCustomCheck(ctx, t, name, req.State, req.Inputs, req.RandomSeed)
 		if err != nil {
 			return p.CheckResponse{}, err
 		}
@@ -165,6 +175,13 @@ func (c *config[T]) configure(ctx context.Context, req p.ConfigureRequest) error
 	return nil
 }
 
+func (c *config[T]) retryPolicy() (RetryPolicy, bool) {
+	if t, ok := any(*c.receiver).(CustomRetryPolicy); ok {
+		return t.RetryPolicy(), true
+	}
+	return RetryPolicy{}, false
+}
+
 func (c *config[T]) handleConfigFailures(ctx context.Context, err mapper.MappingError) error {
 	if err == nil {
 		return nil
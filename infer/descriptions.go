@@ -0,0 +1,52 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	"maps"
+	"reflect"
+	"sync"
+)
+
+// registeredDescriptions maps struct types to the descriptions registered for them with
+// [RegisterDescriptions].
+var registeredDescriptions sync.Map
+
+// RegisterDescriptions sets the descriptions of the type T and of its fields, as if they
+// had been set with [Annotator.Describe]. The description of T is keyed by "", and the
+// descriptions of its fields are keyed by the names in their `pulumi` tags.
+//
+// RegisterDescriptions is usually called from code generated by the describe command,
+// which takes the descriptions from Go doc comments:
+//
+//	//go:generate go run github.com/pulumi/pulumi-go-provider/cmd/describe
+//
+// Descriptions set by the Annotate method of T take precedence over registered ones.
+func RegisterDescriptions[T any](descriptions map[string]string) {
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	registeredDescriptions.Store(t, maps.Clone(descriptions))
+}
+
+// getRegisteredDescriptions returns the descriptions registered for the struct type t.
+func getRegisteredDescriptions(t reflect.Type) map[string]string {
+	d, ok := registeredDescriptions.Load(t)
+	if !ok {
+		return nil
+	}
+	return d.(map[string]string)
+}
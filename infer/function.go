@@ -58,6 +58,9 @@ type InferredFunction interface {
 	schema.Function
 
 	isInferredFunction()
+	withTokenNamer(TokenNamer) InferredFunction
+	// hasResourceRef reports if the inputs or outputs of the function hold a [ResourceRef].
+	hasResourceRef() bool
 }
 
 // Function infers a function from `F`, which maps `I` to `O`.
@@ -67,10 +70,20 @@ func Function[F Fn[I, O], I, O any](fnc F) InferredFunction {
 
 type derivedInvokeController[F Fn[I, O], I, O any] struct {
 	receiver F
+	namer    TokenNamer
 }
 
 func (derivedInvokeController[F, I, O]) isInferredFunction() {}
 
+func (rc derivedInvokeController[F, I, O]) withTokenNamer(namer TokenNamer) InferredFunction {
+	rc.namer = namer
+	return &rc
+}
+
+func (derivedInvokeController[F, I, O]) hasResourceRef() bool {
+	return hasResourceRef[I]() || hasResourceRef[O]()
+}
+
 func (rc *derivedInvokeController[F, I, O]) GetToken() (tokens.Type, error) {
 	// By default, we get resource style tokens:
 	//
@@ -91,7 +104,7 @@ func (rc *derivedInvokeController[F, I, O]) GetToken() (tokens.Type, error) {
 			return tokens.Type(a.Token), nil
 		}
 	}
-	return getToken[F](fnToken)
+	return getToken[F](rc.namer, fnToken)
 }
 
 func fnToken(tk tokens.Type) tokens.Type {
@@ -110,32 +123,33 @@ func fnToken(tk tokens.Type) tokens.Type {
 func (r *derivedInvokeController[F, I, O]) GetSchema(reg schema.RegisterDerivativeType) (pschema.FunctionSpec, error) {
 	descriptions := getAnnotated(reflect.TypeOf(new(F)))
 
-	input, err := objectSchema(reflect.TypeOf(new(I)))
+	input, err := objectSchema(reflect.TypeOf(new(I)), r.namer)
 	if err != nil {
 		return pschema.FunctionSpec{}, err
 	}
-	output, err := objectSchema(reflect.TypeOf(new(O)))
+	output, err := objectSchema(reflect.TypeOf(new(O)), r.namer)
 	if err != nil {
 		return pschema.FunctionSpec{}, err
 	}
 
-	if err := registerTypes[I](reg); err != nil {
+	if err := registerTypes[I](reg, r.namer); err != nil {
 		return pschema.FunctionSpec{}, err
 	}
-	if err := registerTypes[O](reg); err != nil {
+	if err := registerTypes[O](reg, r.namer); err != nil {
 		return pschema.FunctionSpec{}, err
 	}
 
 	return pschema.FunctionSpec{
-		Description: descriptions.Descriptions[""],
+		Description: withExamples(descriptions.Descriptions[""], descriptions.Examples),
 		Inputs:      input,
 		Outputs:     output,
+		Language:    languageSpec(descriptions, ""),
 	}, nil
 }
 
-func objectSchema(t reflect.Type) (*pschema.ObjectTypeSpec, error) {
+func objectSchema(t reflect.Type, namer TokenNamer) (*pschema.ObjectTypeSpec, error) {
 	descriptions := getAnnotated(t)
-	props, required, err := propertyListFromType(t, false, inputType)
+	props, required, err := propertyListFromType(t, false, inputType, namer)
 	if err != nil {
 		return nil, fmt.Errorf("could not serialize input type %s: %w", t, err)
 	}
@@ -147,6 +161,7 @@ func objectSchema(t reflect.Type) (*pschema.ObjectTypeSpec, error) {
 		Properties:  props,
 		Required:    required,
 		Type:        "object",
+		Language:    languageSpec(descriptions, ""),
 	}, nil
 }
 
@@ -16,7 +16,10 @@
 package ende
 
 import (
+	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/pulumi/pulumi-go-provider/infer/types"
 	"github.com/pulumi/pulumi-go-provider/internal/introspect"
@@ -35,6 +38,53 @@ const AssetSignature = "a9e28acb8ab501f883219e7c9f624fb6"
 // ArchiveSignature is a unique key for use for archives in the AssetOrArchive union type.
 const ArchiveSignature = "195f3948f6769324d4661e1e245f3a4d"
 
+// WrappedSignature is the key of the value held by a value wrapper, such as infer.Secret
+// or infer.Unknowable.
+const WrappedSignature = "234846baf2105a1f902a5f1c8fe3b9f8"
+
+// SecretSignature is the key of the flag that marks the value in an infer.Secret as
+// secret.
+const SecretSignature = "21ef42b078aa37125d2a77cff4360ed8"
+
+// UnknownSignature is the key of the flag that marks the value in an infer.Unknowable as
+// unknown.
+const UnknownSignature = "75f5259434a56871129a93096f17eb41"
+
+// Unwrap returns the type held by a value wrapper, such as infer.Secret or
+// infer.Unknowable.
+//
+// If t is not a value wrapper, (t, false) is returned.
+func Unwrap(t reflect.Type) (reflect.Type, bool) {
+	if _, ok := wrapperFlag(t); !ok {
+		return t, false
+	}
+	return t.Field(0).Type, true
+}
+
+// wrapperFlag returns the signature of the flag carried by the value wrapper t.
+//
+// Value wrappers are recognized by their shape: a struct with a value tagged
+// [WrappedSignature] followed by a bool tagged with [SecretSignature] or
+// [UnknownSignature].
+func wrapperFlag(t reflect.Type) (string, bool) {
+	if t == nil || t.Kind() != reflect.Struct || t.NumField() != 2 {
+		return "", false
+	}
+	tagName := func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("pulumi"), ",")
+		return name
+	}
+	if tagName(t.Field(0)) != WrappedSignature || t.Field(1).Type.Kind() != reflect.Bool {
+		return "", false
+	}
+	switch flag := tagName(t.Field(1)); flag {
+	case SecretSignature, UnknownSignature:
+		return flag, true
+	default:
+		return "", false
+	}
+}
+
 // Encoder holds a look-aside table of information that can be encoded into a
 // [resource.PropertyMap] but cannot be encoded into a plain Go struct.
 //
@@ -63,6 +113,13 @@ func DecodeTolerateMissing[T any](m property.Map, dst T) (Encoder, mapper.Mappin
 	return decode(rm, dst, false, true)
 }
 
+// DecodeTolerateUnrecognized decodes m into dst, ignoring properties of m that are not
+// fields of dst.
+func DecodeTolerateUnrecognized(m property.Map, dst any) (Encoder, mapper.MappingError) {
+	rm := resource.ToResourcePropertyValue(property.New(m)).ObjectValue()
+	return decode(rm, dst, true, false)
+}
+
 func DecodeConfig[T any](m property.Map, dst T) (Encoder, mapper.MappingError) {
 	rm := resource.ToResourcePropertyValue(property.New(m)).ObjectValue()
 	return decode(rm, dst, true, false)
@@ -77,10 +134,19 @@ func decode(
 		target = target.Elem()
 	}
 	m = e.simplify(m, target.Type())
-	return Encoder{e}, mapper.New(&mapper.Opts{
+	obj := e.decodeScalars(m.Mappable(), nil, target.Type()).(map[string]any)
+	err := mapper.New(&mapper.Opts{
 		IgnoreUnrecognized: ignoreUnrecognized,
 		IgnoreMissing:      allowMissing,
-	}).Decode(m.Mappable(), target.Addr().Interface())
+	}).Decode(obj, target.Addr().Interface())
+	if len(e.errs) > 0 {
+		errs := e.errs
+		if err != nil {
+			errs = append(errs, err.Failures()...)
+		}
+		err = mapper.NewMappingError(errs)
+	}
+	return Encoder{e}, err
 }
 
 func DecodeAny(m property.Map, dst any) (Encoder, mapper.MappingError) {
@@ -88,8 +154,91 @@ func DecodeAny(m property.Map, dst any) (Encoder, mapper.MappingError) {
 	return decode(rm, dst, false, false)
 }
 
+// ResolveAliases moves properties of m that are set under an alias of a field of typ, as
+// declared with `provider:"alias=oldName"`, to the name of the field. If a property is
+// set under both its name and an alias, the value under the alias is dropped.
+//
+// Decode resolves aliases itself, so ResolveAliases is only needed when m is compared
+// without being decoded.
+func ResolveAliases(m property.Map, typ reflect.Type) property.Map {
+	return resolveAliases(property.New(m), typ).AsMap()
+}
+
+func resolveAliases(v property.Value, typ reflect.Type) property.Value {
+	for typ != nil {
+		if typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		} else if inner, ok := Unwrap(typ); ok {
+			typ = inner
+		} else {
+			break
+		}
+	}
+	if typ == nil {
+		return v
+	}
+
+	switch {
+	case v.IsArray() && (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array):
+		arr := make([]property.Value, 0, v.AsArray().Len())
+		for _, e := range v.AsArray().All {
+			arr = append(arr, resolveAliases(e, typ.Elem()))
+		}
+		return property.WithGoValue(v, property.NewArray(arr))
+	case v.IsMap() && typ.Kind() == reflect.Map:
+		m := make(map[string]property.Value, v.AsMap().Len())
+		for k, e := range v.AsMap().All {
+			m[k] = resolveAliases(e, typ.Elem())
+		}
+		return property.WithGoValue(v, property.NewMap(m))
+	case v.IsMap() && typ.Kind() == reflect.Struct:
+		m := v.AsMap().AsMap()
+		for _, field := range reflect.VisibleFields(typ) {
+			tag, err := introspect.ParseTag(field)
+			if err != nil || tag.Internal {
+				continue
+			}
+			for _, alias := range tag.Aliases {
+				if vAlias, ok := m[alias]; ok {
+					if _, ok := m[tag.Name]; !ok {
+						m[tag.Name] = vAlias
+					}
+					delete(m, alias)
+				}
+			}
+			if vField, ok := m[tag.Name]; ok {
+				m[tag.Name] = resolveAliases(vField, field.Type)
+			}
+		}
+		return property.WithGoValue(v, property.NewMap(m))
+	default:
+		return v
+	}
+}
+
 // An ENcoder DEcoder.
-type ende struct{ changes []change }
+type ende struct {
+	changes []change
+
+	// knownOnly is set when encoded values must not be unknown.
+	knownOnly bool
+
+	// errs holds the values that could not be simplified, such as a value that does not
+	// match exactly one variant of a union.
+	errs []error
+}
+
+// fieldError reports a value that could not be simplified, such as a value that does not
+// match exactly one variant of a union.
+type fieldError struct {
+	field, reason string
+}
+
+func (e fieldError) Error() string  { return e.field + ": " + e.reason }
+func (e fieldError) Field() string  { return e.field }
+func (e fieldError) Reason() string { return e.reason }
+
+var _ mapper.FieldError = fieldError{}
 
 type change struct {
 	path        resource.PropertyPath
@@ -153,6 +302,16 @@ func (e *ende) mark(c change) {
 	e.changes = append(e.changes, c)
 }
 
+// isUnknown reports if the value at path was marked as unknown.
+func (e *ende) isUnknown(path resource.PropertyPath) bool {
+	for _, c := range e.changes {
+		if c.computed && propertyPathEqual(c.path, path) {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *ende) walk(
 	v resource.PropertyValue, path resource.PropertyPath, typ reflect.Type,
 	alignTypes bool,
@@ -164,6 +323,9 @@ func (e *ende) walk(
 		for typ.Kind() == reflect.Pointer {
 			typ = typ.Elem()
 		}
+		if flag, ok := wrapperFlag(typ); ok {
+			return e.walkWrapper(v, path, typ, flag, alignTypes)
+		}
 	}
 
 	switch {
@@ -191,6 +353,29 @@ func (e *ende) walk(
 		return el
 	}
 
+	if variants, ok := UnionVariants(typ); ok {
+		return e.walkUnion(v, path, variants, alignTypes)
+	}
+	if IsReference(typ) {
+		return e.walkReference(v, path, alignTypes)
+	}
+	if typ != nil && IsJSON(typ) {
+		// A JSON document may hold any value, so it is walked without a type. An unknown
+		// document decodes as empty, which is omitted when encoded.
+		if alignTypes {
+			e.mark(change{path: path, emptyAction: isOmitted})
+			return resource.NewNullProperty()
+		}
+		return e.walk(v, path, nil, false)
+	}
+	if typ != nil && IsText(typ) {
+		// Text types are held as strings, whatever their kind.
+		if alignTypes && !v.IsString() {
+			return resource.NewStringProperty("")
+		}
+		return v
+	}
+
 	var elemType reflect.Type
 	if typ != nil {
 		switch typ.Kind() {
@@ -246,6 +431,14 @@ func (e *ende) walk(
 			}
 			pName := resource.PropertyKey(tag.Name)
 			path := append(path, tag.Name)
+			for _, alias := range tag.Aliases {
+				if vAlias, ok := result[resource.PropertyKey(alias)]; ok {
+					if _, ok := result[pName]; !ok {
+						result[pName] = vAlias
+					}
+					delete(result, resource.PropertyKey(alias))
+				}
+			}
 			if vInner, ok := result[pName]; ok {
 				result[pName] = e.walk(vInner, path, field.Type, alignTypes)
 			} else {
@@ -268,7 +461,9 @@ func (e *ende) walk(
 			return v
 		}
 		return resource.NewBoolProperty(false)
-	case reflect.Int, reflect.Int64, reflect.Float32, reflect.Float64:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
 		if v.IsNumber() {
 			return v
 		}
@@ -278,6 +473,28 @@ func (e *ende) walk(
 	}
 }
 
+// walkWrapper moves the secretness or unknownness of v into the flag of the value
+// wrapper typ, so that it is carried by the decoded value instead of the encoder.
+func (e *ende) walkWrapper(
+	v resource.PropertyValue, path resource.PropertyPath,
+	typ reflect.Type, flag string, alignTypes bool,
+) resource.PropertyValue {
+	var set bool
+	switch flag {
+	case SecretSignature:
+		set = putil.IsSecret(v)
+		v = putil.MakePublic(v)
+	case UnknownSignature:
+		set = putil.IsComputed(v)
+		v = putil.MakeKnown(v)
+		alignTypes = alignTypes || set
+	}
+	return resource.NewObjectProperty(resource.PropertyMap{
+		WrappedSignature:           e.walk(v, path, typ.Field(0).Type, alignTypes),
+		resource.PropertyKey(flag): resource.NewBoolProperty(set),
+	})
+}
+
 func (e *ende) walkArray(
 	v resource.PropertyValue, path resource.PropertyPath,
 	elemType reflect.Type, alignTypes bool,
@@ -325,13 +542,28 @@ func (e *ende) walkMap(
 }
 
 func (e *ende) Encode(src any) (resource.PropertyMap, mapper.MappingError) {
+	// Maps with keys that are not strings are hidden from the mapper, and encoded with the
+	// other scalars.
+	var hidden any
+	if src != nil {
+		hidden = hideKeyedMaps(reflect.ValueOf(src)).Interface()
+	}
 	props, err := mapper.New(&mapper.Opts{
 		IgnoreMissing: true,
-	}).Encode(src)
+	}).Encode(hidden)
 	if err != nil {
 		return nil, err
 	}
 
+	var scalarErrs []error
+	props = encodeScalars(props, reflect.ValueOf(src), nil, &scalarErrs).(map[string]any)
+	if len(scalarErrs) > 0 {
+		return nil, mapper.NewMappingError(scalarErrs)
+	}
+	props = flattenUnions(props, reflect.TypeOf(src)).(map[string]any)
+	var wrapped []change
+	props = flattenWrappers(props, nil, &wrapped).(map[string]any)
+
 	m := resource.NewPropertyValueRepl(props,
 		nil, // keys are not changed
 		flattenAssets)
@@ -340,9 +572,32 @@ func (e *ende) Encode(src any) (resource.PropertyMap, mapper.MappingError) {
 		"NewPropertyMapFromMap cannot produce unknown values")
 	contract.Assertf(!m.ContainsSecrets(),
 		"NewPropertyMapFromMap cannot produce secrets")
-	if e == nil {
-		return m.ObjectValue(), nil
+	if e != nil {
+		e.applyChanges(m)
+	}
+
+	// Value wrappers are applied last, since they may mark a value that contains
+	// other changes as secret or computed.
+	var errs []error
+	for _, s := range wrapped {
+		v, ok := getPath(m, s.path)
+		if !ok {
+			continue
+		}
+		if s.computed && e != nil && e.knownOnly {
+			errs = append(errs, fmt.Errorf("%s: value is unknown outside of a preview", s.path))
+			continue
+		}
+		setPath(m, s.path, s.apply(v))
 	}
+	if len(errs) > 0 {
+		return nil, mapper.NewMappingError(errs)
+	}
+
+	return m.ObjectValue(), nil
+}
+
+func (e *ende) applyChanges(m resource.PropertyValue) {
 	for _, s := range e.changes {
 		v, ok := s.path.Get(m)
 		if !ok && s.emptyAction == isNil {
@@ -355,6 +610,8 @@ func (e *ende) Encode(src any) (resource.PropertyMap, mapper.MappingError) {
 				v = resource.NewObjectProperty(resource.PropertyMap{})
 			case isEmptyArr:
 				v = resource.NewArrayProperty([]resource.PropertyValue{})
+			case isOmitted:
+				v = resource.NewNullProperty()
 			default:
 				panic(s.emptyAction)
 			}
@@ -362,14 +619,80 @@ func (e *ende) Encode(src any) (resource.PropertyMap, mapper.MappingError) {
 
 		s.path.Set(m, s.apply(v))
 	}
+}
 
-	return m.ObjectValue(), nil
+// flattenWrappers replaces encoded value wrappers in v with the value they hold,
+// recording the secretness or unknownness of each wrapper in changes.
+//
+// Changes are recorded for inner values before outer values.
+func flattenWrappers(v any, path resource.PropertyPath, changes *[]change) any {
+	switch v := v.(type) {
+	case map[string]any:
+		secret, isSecret := v[SecretSignature].(bool)
+		unknown, isUnknown := v[UnknownSignature].(bool)
+		if isSecret || isUnknown {
+			inner := flattenWrappers(v[WrappedSignature], path, changes)
+			if secret || unknown {
+				*changes = append(*changes, change{
+					path:     slices.Clone(path),
+					secret:   secret,
+					computed: unknown,
+				})
+			}
+			return inner
+		}
+		for k, elem := range v {
+			flat := flattenWrappers(elem, append(path, k), changes)
+			if _, isMap := elem.(map[string]any); isMap && flat == nil {
+				// Only a wrapper holding nil flattens to nil. mapper would have
+				// omitted the nil value had it not been wrapped, so we do too.
+				delete(v, k)
+				continue
+			}
+			v[k] = flat
+		}
+	case []any:
+		for i, elem := range v {
+			v[i] = flattenWrappers(elem, append(path, i), changes)
+		}
+	}
+	return v
+}
+
+// getPath is like [resource.PropertyPath.Get], except that it looks through secret and
+// computed values.
+func getPath(v resource.PropertyValue, path resource.PropertyPath) (resource.PropertyValue, bool) {
+	for _, key := range path {
+		v = putil.MakeKnown(putil.MakePublic(v))
+		var ok bool
+		v, ok = resource.PropertyPath{key}.Get(v)
+		if !ok {
+			return resource.PropertyValue{}, false
+		}
+	}
+	return v, true
+}
+
+// setPath is like [resource.PropertyPath.Set], except that it looks through secret and
+// computed values.
+func setPath(dest resource.PropertyValue, path resource.PropertyPath, v resource.PropertyValue) bool {
+	if len(path) == 0 {
+		return false
+	}
+	dest, ok := getPath(dest, path[:len(path)-1])
+	if !ok {
+		return false
+	}
+	return path[len(path)-1:].Set(putil.MakeKnown(putil.MakePublic(dest)), v)
 }
 
 const (
 	isNil      = iota
 	isEmptyMap = iota
 	isEmptyArr = iota
+	// isOmitted restores a value that is omitted when encoded, such as a union with no
+	// variant, because its value was unknown.
+	isOmitted = iota
 )
 
 // flattenAssets pulls out assets and archives from AssetOrArchive objects.
@@ -435,5 +758,5 @@ func (e *ende) AllowUnknown(allowUnknowns bool) Encoder {
 		changes = append(changes, v)
 	}
 
-	return Encoder{&ende{changes}}
+	return Encoder{&ende{changes: changes, knownOnly: true}}
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/archive"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/asset"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/sig"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"pgregory.net/rapid"
@@ -267,3 +268,187 @@ func TestEncodeAsset(t *testing.T) {
 		})
 	})
 }
+
+// secret and unknowable mirror infer.Secret and infer.Unknowable, which ende recognizes by
+// shape.
+type (
+	secret[T any] struct {
+		Value    T    `pulumi:"234846baf2105a1f902a5f1c8fe3b9f8,optional"`
+		IsSecret bool `pulumi:"21ef42b078aa37125d2a77cff4360ed8,optional"`
+	}
+	unknowable[T any] struct {
+		Value     T    `pulumi:"234846baf2105a1f902a5f1c8fe3b9f8,optional"`
+		IsUnknown bool `pulumi:"75f5259434a56871129a93096f17eb41,optional"`
+	}
+)
+
+func TestRoundtripWrappers(t *testing.T) {
+	t.Parallel()
+
+	type inner struct {
+		Value unknowable[int] `pulumi:"value"`
+	}
+
+	testRoundTrip[struct {
+		Secret       secret[string]              `pulumi:"secret"`
+		Public       secret[string]              `pulumi:"public"`
+		Unknown      unknowable[string]          `pulumi:"unknown"`
+		Known        unknowable[string]          `pulumi:"known"`
+		Both         secret[unknowable[float64]] `pulumi:"both"`
+		List         []secret[bool]              `pulumi:"list"`
+		Nested       secret[inner]               `pulumi:"nested"`
+		SecretParent map[string]secret[string]   `pulumi:"secretParent"`
+	}](t, func() r.PropertyMap {
+		return r.PropertyMap{
+			"secret":  r.MakeSecret(r.NewStringProperty("hidden")),
+			"public":  r.NewStringProperty("shown"),
+			"unknown": r.MakeComputed(r.NewStringProperty("")),
+			"known":   r.NewStringProperty("known"),
+			"both":    r.MakeSecret(r.MakeComputed(r.NewNumberProperty(0))),
+			"list": r.NewArrayProperty([]r.PropertyValue{
+				r.NewBoolProperty(true),
+				r.MakeSecret(r.NewBoolProperty(false)),
+			}),
+			"nested": r.MakeSecret(r.NewObjectProperty(r.PropertyMap{
+				"value": r.MakeComputed(r.NewNumberProperty(0)),
+			})),
+			"secretParent": r.MakeSecret(r.NewObjectProperty(r.PropertyMap{
+				"k": r.MakeSecret(r.NewStringProperty("v")),
+			})),
+		}
+	})
+}
+
+func TestDecodeWrappers(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		Secret  secret[string]     `pulumi:"secret"`
+		Public  secret[*string]    `pulumi:"public,optional"`
+		Unknown unknowable[int]    `pulumi:"unknown"`
+		Known   unknowable[string] `pulumi:"known"`
+	}
+
+	enc, v, err := Decode[args](r.FromResourcePropertyValue(r.NewProperty(r.PropertyMap{
+		"secret":  r.MakeSecret(r.NewStringProperty("hidden")),
+		"public":  r.NewStringProperty("shown"),
+		"unknown": r.MakeComputed(r.NewStringProperty("")),
+		"known":   r.NewStringProperty("value"),
+	})).AsMap())
+	require.NoError(t, err)
+
+	shown := "shown"
+	assert.Equal(t, args{
+		Secret:  secret[string]{Value: "hidden", IsSecret: true},
+		Public:  secret[*string]{Value: &shown},
+		Unknown: unknowable[int]{IsUnknown: true},
+		Known:   unknowable[string]{Value: "value"},
+	}, v)
+
+	t.Run("values are authoritative", func(t *testing.T) {
+		t.Parallel()
+
+		m, err := enc.Encode(args{
+			Secret:  secret[string]{Value: "now public"},
+			Public:  secret[*string]{IsSecret: true},
+			Unknown: unknowable[int]{Value: 3},
+			Known:   unknowable[string]{IsUnknown: true},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, r.PropertyMap{
+			"secret":  r.NewStringProperty("now public"),
+			"unknown": r.NewNumberProperty(3),
+			"known":   r.MakeComputed(r.NewStringProperty("")),
+		}, m)
+	})
+
+	t.Run("unknowns outside of preview", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := enc.AllowUnknown(false).Encode(v)
+		assert.ErrorContains(t, err, "unknown: value is unknown outside of a preview")
+	})
+}
+
+func TestUnwrap(t *testing.T) {
+	t.Parallel()
+
+	inner, ok := Unwrap(reflect.TypeFor[secret[[]string]]())
+	assert.True(t, ok)
+	assert.Equal(t, reflect.TypeFor[[]string](), inner)
+
+	inner, ok = Unwrap(reflect.TypeFor[unknowable[int]]())
+	assert.True(t, ok)
+	assert.Equal(t, reflect.TypeFor[int](), inner)
+
+	_, ok = Unwrap(reflect.TypeFor[types.AssetOrArchive]())
+	assert.False(t, ok)
+	_, ok = Unwrap(reflect.TypeFor[string]())
+	assert.False(t, ok)
+}
+
+func TestAliases(t *testing.T) {
+	t.Parallel()
+
+	type nested struct {
+		Name string `pulumi:"name" provider:"alias=bucketName"`
+	}
+	type args struct {
+		Name   string            `pulumi:"name" provider:"alias=bucketName,alias=oldName"`
+		Nested []nested          `pulumi:"nested,optional"`
+		ByKey  map[string]nested `pulumi:"byKey,optional"`
+	}
+
+	old := property.NewMap(map[string]property.Value{
+		"bucketName": property.New("b").WithSecret(true),
+		"nested": property.New([]property.Value{
+			property.New(map[string]property.Value{"bucketName": property.New("n")}),
+		}),
+		"byKey": property.New(map[string]property.Value{
+			"k": property.New(map[string]property.Value{"bucketName": property.New("k")}),
+		}).WithSecret(true),
+	})
+	current := property.NewMap(map[string]property.Value{
+		"name": property.New("b").WithSecret(true),
+		"nested": property.New([]property.Value{
+			property.New(map[string]property.Value{"name": property.New("n")}),
+		}),
+		"byKey": property.New(map[string]property.Value{
+			"k": property.New(map[string]property.Value{"name": property.New("k")}),
+		}).WithSecret(true),
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		t.Parallel()
+
+		enc, v, err := Decode[args](old)
+		require.NoError(t, err)
+		assert.Equal(t, args{
+			Name:   "b",
+			Nested: []nested{{Name: "n"}},
+			ByKey:  map[string]nested{"k": {Name: "k"}},
+		}, v)
+
+		m, err := enc.Encode(v)
+		require.NoError(t, err)
+		assert.Equal(t, current, r.FromResourcePropertyValue(r.NewProperty(m)).AsMap())
+	})
+
+	t.Run("resolve", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, current, ResolveAliases(old, reflect.TypeFor[args]()))
+		assert.Equal(t, current, ResolveAliases(current, reflect.TypeFor[args]()))
+	})
+
+	t.Run("current name wins", func(t *testing.T) {
+		t.Parallel()
+
+		_, v, err := Decode[args](property.NewMap(map[string]property.Value{
+			"name":    property.New("new"),
+			"oldName": property.New("old"),
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "new", v.Name)
+	})
+}
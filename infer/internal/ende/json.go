@@ -0,0 +1,64 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ende
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/pulumi/pulumi-go-provider/infer/types"
+)
+
+var (
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+	jsonType       = reflect.TypeFor[types.JSON]()
+)
+
+// IsJSON reports if values of t are JSON documents, which are represented as the
+// structured values they encode.
+func IsJSON(t reflect.Type) bool {
+	return t == rawMessageType || t == jsonType
+}
+
+// marshalJSON returns v, the mappable form of a JSON document, as a value of the JSON type
+// t.
+//
+// The document is compact, and the keys of objects are sorted.
+func marshalJSON(v any, t reflect.Type) (any, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	// The document is data, not HTML.
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	b := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+	return reflect.ValueOf(b).Convert(t).Interface(), nil
+}
+
+// unmarshalJSON returns the mappable form of src, a value of a JSON type. An empty
+// document is nil.
+func unmarshalJSON(src reflect.Value) (any, error) {
+	if src.Len() == 0 {
+		return nil, nil
+	}
+	var v any
+	if err := json.Unmarshal(src.Bytes(), &v); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return v, nil
+}
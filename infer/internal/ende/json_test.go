@@ -0,0 +1,143 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ende
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	r "github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi-go-provider/infer/types"
+)
+
+func TestIsJSON(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsJSON(reflect.TypeFor[json.RawMessage]()))
+	assert.True(t, IsJSON(reflect.TypeFor[types.JSON]()))
+	assert.False(t, IsJSON(reflect.TypeFor[[]byte]()))
+	assert.False(t, IsJSON(reflect.TypeFor[map[string]any]()))
+}
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		Policy types.JSON             `pulumi:"policy"`
+		Tags   json.RawMessage        `pulumi:"tags,optional"`
+		Extra  []types.JSON           `pulumi:"extra,optional"`
+		Named  map[string]*types.JSON `pulumi:"named,optional"`
+	}
+
+	t.Run("roundtrip", func(t *testing.T) {
+		t.Parallel()
+
+		m := property.NewMap(map[string]property.Value{
+			"policy": property.New(map[string]property.Value{
+				"version": property.New("2012-10-17"),
+				"statement": property.New([]property.Value{
+					property.New(map[string]property.Value{
+						"effect":    property.New("Allow"),
+						"condition": property.New("a < b && c > d"),
+						"weight":    property.New(1.5),
+						"enabled":   property.New(true),
+						"principal": property.New(property.Null),
+					}),
+				}),
+			}),
+			"tags":  property.New([]property.Value{property.New("a"), property.New(2.0)}),
+			"extra": property.New([]property.Value{property.New("text"), property.New(3.0)}),
+			"named": property.New(map[string]property.Value{
+				"flag": property.New(false),
+			}),
+		})
+
+		enc, v, err := Decode[args](m)
+		require.NoError(t, err)
+		flag := types.JSON(`false`)
+		assert.Equal(t, args{
+			Policy: types.JSON(`{"statement":[{"condition":"a < b && c > d","effect":"Allow",` +
+				`"enabled":true,"principal":null,"weight":1.5}],"version":"2012-10-17"}`),
+			Tags:  json.RawMessage(`["a",2]`),
+			Extra: []types.JSON{types.JSON(`"text"`), types.JSON(`3`)},
+			Named: map[string]*types.JSON{"flag": &flag},
+		}, v)
+
+		actual, err := enc.Encode(v)
+		require.NoError(t, err)
+		assert.Equal(t, m, r.FromResourcePropertyValue(r.NewProperty(actual)).AsMap())
+	})
+
+	t.Run("secrets", func(t *testing.T) {
+		t.Parallel()
+
+		m := property.NewMap(map[string]property.Value{
+			"policy": property.New(map[string]property.Value{
+				"token": property.New("hunter2").WithSecret(true),
+				"items": property.New([]property.Value{
+					property.New(1.0),
+					property.New(map[string]property.Value{
+						"key": property.New("k").WithSecret(true),
+					}),
+				}),
+			}),
+			"tags": property.New([]property.Value{property.New("a")}).WithSecret(true),
+		})
+
+		enc, v, err := Decode[args](m)
+		require.NoError(t, err)
+		assert.Equal(t, args{
+			Policy: types.JSON(`{"items":[1,{"key":"k"}],"token":"hunter2"}`),
+			Tags:   json.RawMessage(`["a"]`),
+		}, v)
+
+		actual, err := enc.Encode(v)
+		require.NoError(t, err)
+		assert.Equal(t, m, r.FromResourcePropertyValue(r.NewProperty(actual)).AsMap())
+	})
+
+	t.Run("unknowns", func(t *testing.T) {
+		t.Parallel()
+
+		m := property.NewMap(map[string]property.Value{
+			"policy": property.New(property.Computed),
+			"tags": property.New(map[string]property.Value{
+				"owner": property.New(property.Computed),
+				"team":  property.New("infra"),
+			}),
+		})
+
+		enc, v, err := Decode[args](m)
+		require.NoError(t, err)
+		assert.Empty(t, v.Policy)
+
+		actual, err := enc.Encode(v)
+		require.NoError(t, err)
+		assert.Equal(t, m, r.FromResourcePropertyValue(r.NewProperty(actual)).AsMap())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := (*ende)(nil).Encode(args{Policy: types.JSON(`{"a":`)})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "policy: invalid JSON")
+	})
+}
@@ -0,0 +1,280 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ende
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/mapper"
+)
+
+var (
+	stringType = reflect.TypeFor[string]()
+	anyType    = reflect.TypeFor[any]()
+)
+
+// IsMapKey reports if t can be the key type of a map. Keys are written as strings, so t
+// must be a string type, an integer type or a text type (see [IsText]).
+func IsMapKey(t reflect.Type) bool {
+	if IsText(t) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// formatKey returns the string form of the map key k.
+func formatKey(k reflect.Value) (string, error) {
+	if IsText(k.Type()) {
+		return formatText(k)
+	}
+	switch k.Kind() {
+	case reflect.String:
+		return k.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("%s is not a valid map key", k.Type())
+	}
+}
+
+// parseKey parses s as a map key of type t.
+//
+// If t is an enum, s must be one of its values.
+func parseKey(s string, t reflect.Type) (reflect.Value, error) {
+	key := reflect.New(t).Elem()
+	switch {
+	case t == durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return key, fmt.Errorf("invalid key %q: expected a duration such as \"1h30m\"", s)
+		}
+		key.SetInt(int64(d))
+	case IsText(t):
+		if err := key.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return key, fmt.Errorf("invalid key %q: %w", s, err)
+		}
+	case t.Kind() == reflect.String:
+		key.SetString(s)
+	case key.CanInt():
+		i, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			lo, hi, _ := NumberRange(t)
+			return key, fmt.Errorf("invalid key %q: expected an integer between %s and %s", s, lo, hi)
+		}
+		key.SetInt(i)
+	case key.CanUint():
+		u, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			lo, hi, _ := NumberRange(t)
+			return key, fmt.Errorf("invalid key %q: expected an integer between %s and %s", s, lo, hi)
+		}
+		key.SetUint(u)
+	default:
+		return key, fmt.Errorf("%s is not a valid map key", t)
+	}
+
+	if values, ok := enumValues(t); ok {
+		names := make([]string, len(values))
+		for i, v := range values {
+			if v.Equal(key) {
+				return key, nil
+			}
+			names[i], _ = formatKey(v)
+		}
+		return key, fmt.Errorf("invalid key %q: must be one of %s", s, quoteAll(names))
+	}
+	return key, nil
+}
+
+// enumValues returns the values of the enum type t.
+//
+// Enums are recognized by their shape: a Values method that returns a slice of structs with
+// a Value field of type t, such as []infer.EnumValue[T].
+func enumValues(t reflect.Type) ([]reflect.Value, bool) {
+	m, ok := reflect.PointerTo(t).MethodByName("Values")
+	if !ok || m.Type.NumIn() != 1 || m.Type.NumOut() != 1 || m.Type.Out(0).Kind() != reflect.Slice {
+		return nil, false
+	}
+	elem := m.Type.Out(0).Elem()
+	if elem.Kind() != reflect.Struct {
+		return nil, false
+	}
+	field, ok := elem.FieldByName("Value")
+	if !ok || field.Type != t {
+		return nil, false
+	}
+
+	out := m.Func.Call([]reflect.Value{reflect.New(t)})[0]
+	values := make([]reflect.Value, out.Len())
+	for i := range values {
+		values[i] = out.Index(i).FieldByIndex(field.Index)
+	}
+	return values, true
+}
+
+// decodeKeys parses the keys of m as keys of type t, so that the mapper can decode m into a
+// map with keys of type t. Keys that cannot be parsed are recorded as errors.
+func (e *ende) decodeKeys(m map[string]any, path resource.PropertyPath, t reflect.Type) any {
+	typed := reflect.MakeMapWithSize(reflect.MapOf(t, anyType), len(m))
+	var invalid []string
+	for k, elem := range m {
+		key, err := parseKey(k, t)
+		if err != nil {
+			invalid = append(invalid, err.Error())
+			continue
+		}
+		value := reflect.Zero(anyType)
+		if elem != nil {
+			value = reflect.ValueOf(elem)
+		}
+		typed.SetMapIndex(key, value)
+	}
+	if len(invalid) > 0 {
+		// Map iteration is random, so keep the reported order stable.
+		slices.Sort(invalid)
+		e.errs = append(e.errs, fieldError{path.String(), strings.Join(invalid, "; ")})
+	}
+	return typed.Interface()
+}
+
+// encodeKeyedMap encodes src, a map with keys that are not strings, with its keys in text
+// form.
+func encodeKeyedMap(src reflect.Value, path resource.PropertyPath, errs *[]error) any {
+	if src.IsNil() {
+		return nil
+	}
+	m := make(map[string]any, src.Len())
+	for iter := src.MapRange(); iter.Next(); {
+		k, err := formatKey(iter.Key())
+		if err != nil {
+			*errs = append(*errs, fieldError{path.String(), err.Error()})
+			continue
+		}
+		elem, mErr := mapper.New(&mapper.Opts{
+			IgnoreMissing: true,
+		}).EncodeValue(hideKeyedMaps(iter.Value()).Interface())
+		if mErr != nil {
+			*errs = append(*errs, mErr.Failures()...)
+			continue
+		}
+		m[k] = encodeScalars(elem, iter.Value(), append(path, k), errs)
+	}
+	return m
+}
+
+// hideKeyedMaps returns a copy of v where each map with keys that are not strings is nil.
+//
+// The mapper can only encode maps with string keys, so these maps are hidden from it and
+// encoded by [encodeScalars] instead. Values that hold no such maps are not copied.
+func hideKeyedMaps(v reflect.Value) reflect.Value {
+	if !v.IsValid() || !hasKeyedMap(v.Type(), map[reflect.Type]bool{}) {
+		return v
+	}
+	t := v.Type()
+	switch t.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(t.Elem())
+		c.Elem().Set(hideKeyedMaps(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(t).Elem()
+		c.Set(hideKeyedMaps(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(t).Elem()
+		c.Set(v)
+		for i := range t.NumField() {
+			if f := t.Field(i); f.IsExported() && hasKeyedMap(f.Type, map[reflect.Type]bool{}) {
+				c.Field(i).Set(hideKeyedMaps(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(t, v.Len(), v.Len())
+		for i := range v.Len() {
+			c.Index(i).Set(hideKeyedMaps(v.Index(i)))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(t).Elem()
+		for i := range v.Len() {
+			c.Index(i).Set(hideKeyedMaps(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return reflect.Zero(t)
+		}
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(t, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			c.SetMapIndex(iter.Key(), hideKeyedMaps(iter.Value()))
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+// hasKeyedMap reports if values of t may hold a map with keys that are not strings.
+func hasKeyedMap(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Interface:
+		// The value held is not known from the type.
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return hasKeyedMap(t.Elem(), seen)
+	case reflect.Map:
+		return t.Key().Kind() != reflect.String || hasKeyedMap(t.Elem(), seen)
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if f := t.Field(i); f.IsExported() && hasKeyedMap(f.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
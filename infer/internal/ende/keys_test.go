@@ -0,0 +1,146 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ende
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	r "github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/mapper"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type region string
+
+type regionValue struct{ Value region }
+
+func (region) Values() []regionValue {
+	return []regionValue{{"us-east-1"}, {"eu-west-1"}}
+}
+
+type tier int
+
+type tierValue struct{ Value tier }
+
+func (tier) Values() []tierValue { return []tierValue{{1}, {2}} }
+
+func TestIsMapKey(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsMapKey(reflect.TypeFor[string]()))
+	assert.True(t, IsMapKey(reflect.TypeFor[region]()))
+	assert.True(t, IsMapKey(reflect.TypeFor[uint8]()))
+	assert.True(t, IsMapKey(reflect.TypeFor[netip.Addr]()))
+	assert.False(t, IsMapKey(reflect.TypeFor[float64]()))
+	assert.False(t, IsMapKey(reflect.TypeFor[s3Source]()))
+}
+
+func TestMapKeys(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Size  int                   `pulumi:"size"`
+		Hosts map[netip.Addr]string `pulumi:"hosts,optional"`
+	}
+	type args struct {
+		Regions map[region]config      `pulumi:"regions"`
+		Tiers   map[tier]string        `pulumi:"tiers,optional"`
+		Ports   map[uint16]string      `pulumi:"ports,optional"`
+		Delays  *map[time.Duration]int `pulumi:"delays,optional"`
+		Nested  []map[int8]bool        `pulumi:"nested,optional"`
+	}
+
+	t.Run("roundtrip", func(t *testing.T) {
+		t.Parallel()
+
+		m := property.NewMap(map[string]property.Value{
+			"regions": property.New(map[string]property.Value{
+				"us-east-1": property.New(map[string]property.Value{
+					"size": property.New(3.0),
+					"hosts": property.New(map[string]property.Value{
+						"10.0.0.1": property.New("db").WithSecret(true),
+					}),
+				}),
+			}),
+			"tiers": property.New(map[string]property.Value{"2": property.New("gold")}),
+			"ports": property.New(map[string]property.Value{"443": property.New("https")}),
+			"delays": property.New(map[string]property.Value{
+				"1m0s": property.New(3.0),
+			}),
+			"nested": property.New([]property.Value{
+				property.New(map[string]property.Value{"-1": property.New(true)}),
+			}),
+		})
+
+		enc, v, err := Decode[args](m)
+		require.NoError(t, err)
+		assert.Equal(t, args{
+			Regions: map[region]config{
+				"us-east-1": {
+					Size:  3,
+					Hosts: map[netip.Addr]string{netip.MustParseAddr("10.0.0.1"): "db"},
+				},
+			},
+			Tiers:  map[tier]string{2: "gold"},
+			Ports:  map[uint16]string{443: "https"},
+			Delays: &map[time.Duration]int{time.Minute: 3},
+			Nested: []map[int8]bool{{-1: true}},
+		}, v)
+
+		actual, err := enc.Encode(v)
+		require.NoError(t, err)
+		assert.Equal(t, m, r.FromResourcePropertyValue(r.NewProperty(actual)).AsMap())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := Decode[args](property.NewMap(map[string]property.Value{
+			"regions": property.New(map[string]property.Value{
+				"mars-1": property.New(map[string]property.Value{"size": property.New(1.0)}),
+				"eu-west-1": property.New(map[string]property.Value{
+					"size": property.New(1.0),
+					"hosts": property.New(map[string]property.Value{
+						"localhost": property.New("db"),
+					}),
+				}),
+			}),
+			"tiers": property.New(map[string]property.Value{"3": property.New("platinum")}),
+			"ports": property.New(map[string]property.Value{
+				"http":  property.New("http"),
+				"70000": property.New("unknown"),
+			}),
+		}))
+		require.Error(t, err)
+
+		reasons := map[string]string{}
+		for _, failure := range err.Failures() {
+			failure := failure.(mapper.FieldError)
+			reasons[failure.Field()] = failure.Reason()
+		}
+		assert.Equal(t, map[string]string{
+			"regions":                    `invalid key "mars-1": must be one of "us-east-1", "eu-west-1"`,
+			`regions["eu-west-1"].hosts`: `invalid key "localhost": ParseAddr("localhost"): unable to parse IP`,
+			"tiers":                      `invalid key "3": must be one of "1", "2"`,
+			"ports": `invalid key "70000": expected an integer between 0 and 65535; ` +
+				`invalid key "http": expected an integer between 0 and 65535`,
+		}, reasons)
+	})
+}
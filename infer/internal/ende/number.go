@@ -0,0 +1,83 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ende
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+func isNumber(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// NumberRange returns the smallest and the largest value of the numeric type t.
+func NumberRange(t reflect.Type) (lo, hi string, ok bool) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := t.Bits()
+		return strconv.FormatInt(int64(-1)<<(bits-1), 10), strconv.FormatInt(int64(1)<<(bits-1)-1, 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "0", strconv.FormatUint(^uint64(0)>>(64-t.Bits()), 10), true
+	case reflect.Float32:
+		return strconv.FormatFloat(-math.MaxFloat32, 'g', -1, 64), strconv.FormatFloat(math.MaxFloat32, 'g', -1, 64), true
+	case reflect.Float64:
+		return strconv.FormatFloat(-math.MaxFloat64, 'g', -1, 64), strconv.FormatFloat(math.MaxFloat64, 'g', -1, 64), true
+	default:
+		return "", "", false
+	}
+}
+
+// checkNumber returns an error if f cannot be held by the numeric type t without losing
+// its value.
+func checkNumber(f float64, t reflect.Type) error {
+	zero := reflect.Zero(t)
+	outOfRange := func() error {
+		lo, hi, _ := NumberRange(t)
+		return fmt.Errorf("%v is out of range for %s: must be between %s and %s", f, t, lo, hi)
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if f != math.Trunc(f) {
+			return fmt.Errorf("%v is not an integer", f)
+		}
+		// Values outside of [-2^63, 2^63) don't convert to int64.
+		if f < -(1<<63) || f >= 1<<63 || zero.OverflowInt(int64(f)) {
+			return outOfRange()
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if f != math.Trunc(f) {
+			return fmt.Errorf("%v is not an integer", f)
+		}
+		// Values outside of [0, 2^64) don't convert to uint64.
+		if f < 0 || f >= 1<<64 || zero.OverflowUint(uint64(f)) {
+			return outOfRange()
+		}
+	case reflect.Float32:
+		if zero.OverflowFloat(f) {
+			return outOfRange()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ende
+
+import (
+	"reflect"
+	"testing"
+
+	r "github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/mapper"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumberRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		typ    reflect.Type
+		lo, hi string
+	}{
+		{reflect.TypeFor[int8](), "-128", "127"},
+		{reflect.TypeFor[int32](), "-2147483648", "2147483647"},
+		{reflect.TypeFor[int64](), "-9223372036854775808", "9223372036854775807"},
+		{reflect.TypeFor[uint16](), "0", "65535"},
+		{reflect.TypeFor[uint64](), "0", "18446744073709551615"},
+		{reflect.TypeFor[float32](), "-3.4028234663852886e+38", "3.4028234663852886e+38"},
+	}
+	for _, tt := range tests {
+		lo, hi, ok := NumberRange(tt.typ)
+		assert.True(t, ok, tt.typ.String())
+		assert.Equal(t, tt.lo, lo, tt.typ.String())
+		assert.Equal(t, tt.hi, hi, tt.typ.String())
+	}
+
+	_, _, ok := NumberRange(reflect.TypeFor[string]())
+	assert.False(t, ok)
+}
+
+func TestNumbers(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		Port    uint16    `pulumi:"port"`
+		Ratio   float32   `pulumi:"ratio"`
+		Count   *int32    `pulumi:"count,optional"`
+		Offsets []int8    `pulumi:"offsets,optional"`
+		Size    *uint64   `pulumi:"size,optional"`
+		Weights []float32 `pulumi:"weights,optional"`
+	}
+
+	t.Run("roundtrip", func(t *testing.T) {
+		t.Parallel()
+
+		m := property.NewMap(map[string]property.Value{
+			"port":    property.New(8080.0),
+			"ratio":   property.New(0.5),
+			"count":   property.New(-3.0).WithSecret(true),
+			"offsets": property.New([]property.Value{property.New(-128.0), property.New(127.0)}),
+			"size":    property.New(1e15),
+		})
+
+		enc, v, err := Decode[args](m)
+		require.NoError(t, err)
+		assert.Equal(t, args{
+			Port:    8080,
+			Ratio:   0.5,
+			Count:   ref[int32](-3),
+			Offsets: []int8{-128, 127},
+			Size:    ref[uint64](1e15),
+		}, v)
+
+		actual, err := enc.Encode(v)
+		require.NoError(t, err)
+		assert.Equal(t, m, r.FromResourcePropertyValue(r.NewProperty(actual)).AsMap())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		m := property.NewMap(map[string]property.Value{
+			"port":  property.New(property.Computed),
+			"ratio": property.New(0.25),
+		})
+
+		enc, v, err := Decode[args](m)
+		require.NoError(t, err)
+		assert.Equal(t, args{Ratio: 0.25}, v)
+
+		actual, err := enc.Encode(v)
+		require.NoError(t, err)
+		assert.Equal(t, m, r.FromResourcePropertyValue(r.NewProperty(actual)).AsMap())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := Decode[args](property.NewMap(map[string]property.Value{
+			"port":    property.New(70000.0),
+			"ratio":   property.New(1e39),
+			"count":   property.New(3e10),
+			"offsets": property.New([]property.Value{property.New(1.5)}),
+			"size":    property.New(-1.0),
+			"weights": property.New([]property.Value{property.New(1.0)}),
+		}))
+		require.Error(t, err)
+
+		reasons := map[string]string{}
+		for _, failure := range err.Failures() {
+			failure := failure.(mapper.FieldError)
+			reasons[failure.Field()] = failure.Reason()
+		}
+		assert.Equal(t, map[string]string{
+			"port": "70000 is out of range for uint16: must be between 0 and 65535",
+			"ratio": "1e+39 is out of range for float32: " +
+				"must be between -3.4028234663852886e+38 and 3.4028234663852886e+38",
+			"count":      "3e+10 is out of range for int32: must be between -2147483648 and 2147483647",
+			"offsets[0]": "1.5 is not an integer",
+			"size":       "-1 is out of range for uint64: must be between 0 and 18446744073709551615",
+		}, reasons)
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ende
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// ReferenceSignature prefixes the keys of the fields of a resource reference type, such as
+// infer.ResourceRef. The key of the i-th field is ReferenceSignature followed by i.
+const ReferenceSignature = "5e0b8d2a71c94f36a8e1d7b04c6f2935"
+
+// IsReference reports if t is a resource reference type.
+//
+// Resource reference types are recognized by their shape: a struct of a URN, an ID and a
+// package version, where the i-th field is a string tagged with [ReferenceSignature]
+// followed by i.
+func IsReference(t reflect.Type) bool {
+	if t == nil || t.Kind() != reflect.Struct || t.NumField() != 3 {
+		return false
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("pulumi"), ",")
+		if name != referenceKey(i) || f.Type.Kind() != reflect.String {
+			return false
+		}
+	}
+	return true
+}
+
+func referenceKey(i int) string { return ReferenceSignature + strconv.Itoa(i) }
+
+// walkReference replaces the resource reference v with the fields of a resource
+// reference type.
+func (e *ende) walkReference(
+	v resource.PropertyValue, path resource.PropertyPath, alignTypes bool,
+) resource.PropertyValue {
+	// An unknown reference is empty, which is omitted when encoded.
+	if alignTypes {
+		e.mark(change{path: path, emptyAction: isOmitted})
+		return resource.NewObjectProperty(resource.PropertyMap{})
+	}
+	if v.IsNull() {
+		return v
+	}
+	if !v.IsResourceReference() {
+		e.errs = append(e.errs, fieldError{path.String(), "expected a resource reference"})
+		return resource.NewObjectProperty(resource.PropertyMap{})
+	}
+	ref := v.ResourceReferenceValue()
+	// An ID that is not yet known is left empty.
+	id, _ := ref.IDString()
+	return resource.NewObjectProperty(resource.PropertyMap{
+		resource.PropertyKey(referenceKey(0)): resource.NewStringProperty(string(ref.URN)),
+		resource.PropertyKey(referenceKey(1)): resource.NewStringProperty(id),
+		resource.PropertyKey(referenceKey(2)): resource.NewStringProperty(ref.PackageVersion),
+	})
+}
+
+// encodeReference returns src, a value of a resource reference type, as a resource
+// reference. An empty ID is unknown, and a reference without a URN is nil.
+func encodeReference(src reflect.Value) any {
+	if src.Field(0).String() == "" {
+		return nil
+	}
+	return resource.MakeCustomResourceReference(
+		resource.URN(src.Field(0).String()),
+		resource.ID(src.Field(1).String()),
+		src.Field(2).String(),
+	)
+}
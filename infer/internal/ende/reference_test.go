@@ -0,0 +1,128 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ende
+
+import (
+	"reflect"
+	"testing"
+
+	r "github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/mapper"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resourceRef matches the shape of infer.ResourceRef.
+type resourceRef struct {
+	URN            r.URN  `pulumi:"5e0b8d2a71c94f36a8e1d7b04c6f29350,optional"`
+	ID             r.ID   `pulumi:"5e0b8d2a71c94f36a8e1d7b04c6f29351,optional"`
+	PackageVersion string `pulumi:"5e0b8d2a71c94f36a8e1d7b04c6f29352,optional"`
+}
+
+func TestIsReference(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsReference(reflect.TypeFor[resourceRef]()))
+	assert.False(t, IsReference(reflect.TypeFor[*resourceRef]()))
+	assert.False(t, IsReference(reflect.TypeFor[s3Source]()))
+	assert.False(t, IsReference(nil))
+}
+
+func TestReference(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		Network resourceRef   `pulumi:"network"`
+		Peer    *resourceRef  `pulumi:"peer,optional"`
+		Routes  []resourceRef `pulumi:"routes,optional"`
+	}
+
+	const (
+		networkURN = "urn:pulumi:stack::project::test:index:Network::net"
+		peerURN    = "urn:pulumi:stack::project::test:index:Network::peer"
+	)
+
+	t.Run("roundtrip", func(t *testing.T) {
+		t.Parallel()
+
+		m := r.FromResourcePropertyMap(r.PropertyMap{
+			"network": r.MakeCustomResourceReference(networkURN, "net-1234", "1.0.0"),
+			"peer":    r.MakeSecret(r.MakeCustomResourceReference(peerURN, "net-5678", "")),
+			"routes": r.NewArrayProperty([]r.PropertyValue{
+				r.MakeCustomResourceReference(peerURN, "net-5678", ""),
+			}),
+		})
+
+		enc, v, err := Decode[args](m)
+		require.NoError(t, err)
+		assert.Equal(t, args{
+			Network: resourceRef{URN: networkURN, ID: "net-1234", PackageVersion: "1.0.0"},
+			Peer:    &resourceRef{URN: peerURN, ID: "net-5678"},
+			Routes:  []resourceRef{{URN: peerURN, ID: "net-5678"}},
+		}, v)
+
+		actual, err := enc.Encode(v)
+		require.NoError(t, err)
+		assert.Equal(t, m, r.FromResourcePropertyValue(r.NewProperty(actual)).AsMap())
+	})
+
+	t.Run("unknown id", func(t *testing.T) {
+		t.Parallel()
+
+		m := r.FromResourcePropertyMap(r.PropertyMap{
+			"network": r.MakeCustomResourceReference(networkURN, "", "1.0.0"),
+		})
+
+		enc, v, err := Decode[args](m)
+		require.NoError(t, err)
+		assert.Equal(t, args{
+			Network: resourceRef{URN: networkURN, PackageVersion: "1.0.0"},
+		}, v)
+
+		actual, err := enc.Encode(v)
+		require.NoError(t, err)
+		assert.Equal(t, m, r.FromResourcePropertyValue(r.NewProperty(actual)).AsMap())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		m := property.NewMap(map[string]property.Value{
+			"network": property.New(property.Computed),
+		})
+
+		enc, v, err := Decode[args](m)
+		require.NoError(t, err)
+		assert.Equal(t, args{}, v)
+
+		actual, err := enc.Encode(v)
+		require.NoError(t, err)
+		assert.Equal(t, m, r.FromResourcePropertyValue(r.NewProperty(actual)).AsMap())
+	})
+
+	t.Run("not a reference", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := Decode[args](property.NewMap(map[string]property.Value{
+			"network": property.New("net-1234"),
+		}))
+		require.Error(t, err)
+		require.Len(t, err.Failures(), 1)
+		failure := err.Failures()[0].(mapper.FieldError)
+		assert.Equal(t, "network", failure.Field())
+		assert.Equal(t, "expected a resource reference", failure.Reason())
+	})
+}
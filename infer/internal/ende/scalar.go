@@ -0,0 +1,245 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ende
+
+import (
+	"reflect"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+
+	"github.com/pulumi/pulumi-go-provider/internal/introspect"
+)
+
+// decodeScalars prepares the scalars in v, the mappable form of a value of type t, for the
+// mapper. Values that do not fit their type are recorded as errors.
+//
+// JSON documents are marshaled from the values they hold, since the mapper cannot decode an
+// object into a []byte. The strings held by text types are replaced with the values they
+// describe, since the mapper would otherwise convert a string to a named []byte or int64
+// directly, instead of parsing it. Numbers are checked against the range of their type, since the mapper would
+// otherwise truncate them. The keys of maps are parsed as the key type of the map.
+func (e *ende) decodeScalars(v any, path resource.PropertyPath, t reflect.Type) any {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t != nil && IsJSON(t) && v == nil && e.isUnknown(path) {
+		// An unknown document is empty, so that the mapper does not report it as missing.
+		return reflect.Zero(t).Interface()
+	}
+	if t == nil || v == nil {
+		return v
+	}
+
+	if IsJSON(t) {
+		doc, err := marshalJSON(v, t)
+		if err != nil {
+			e.errs = append(e.errs, fieldError{path.String(), err.Error()})
+			return reflect.Zero(t).Interface()
+		}
+		return doc
+	}
+	if IsText(t) {
+		s, ok := v.(string)
+		if !ok {
+			// Leave the mapper to report the mismatched type.
+			return v
+		}
+		if s == "" && e.isUnknown(path) {
+			// An unknown value is held as the empty string, which may not parse.
+			return reflect.Zero(t).Interface()
+		}
+		value, err := parseText(s, t)
+		if err != nil {
+			e.errs = append(e.errs, fieldError{path.String(), err.Error()})
+			return reflect.Zero(t).Interface()
+		}
+		return value
+	}
+	if f, ok := v.(float64); ok && isNumber(t) {
+		if err := checkNumber(f, t); err != nil {
+			e.errs = append(e.errs, fieldError{path.String(), err.Error()})
+			return reflect.Zero(t).Interface()
+		}
+		return v
+	}
+	if variants, ok := UnionVariants(t); ok {
+		if m, ok := v.(map[string]any); ok {
+			for i, variant := range variants {
+				if inner, ok := m[unionKey(i)]; ok {
+					m[unionKey(i)] = e.decodeScalars(inner, path, variant)
+				}
+			}
+		}
+		return v
+	}
+	if inner, ok := Unwrap(t); ok {
+		if m, ok := v.(map[string]any); ok {
+			if held, ok := m[WrappedSignature]; ok {
+				m[WrappedSignature] = e.decodeScalars(held, path, inner)
+			}
+		}
+		return v
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Slice:
+		if arr, ok := v.([]any); ok {
+			for i, elem := range arr {
+				arr[i] = e.decodeScalars(elem, append(path, i), t.Elem())
+			}
+		}
+	case reflect.Map:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		for k, elem := range m {
+			m[k] = e.decodeScalars(elem, append(path, k), t.Elem())
+		}
+		if t.Key() != stringType {
+			return e.decodeKeys(m, path, t.Key())
+		}
+	case reflect.Struct:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		for _, field := range reflect.VisibleFields(t) {
+			tag, err := introspect.ParseTag(field)
+			if err != nil || tag.Internal {
+				continue
+			}
+			if elem, ok := m[tag.Name]; ok {
+				m[tag.Name] = e.decodeScalars(elem, append(path, tag.Name), field.Type)
+			}
+		}
+	}
+	return v
+}
+
+// encodeScalars writes the scalars held by src in v, the mappable form of src. v is encoded
+// from [hideKeyedMaps] of src.
+//
+// JSON documents are replaced with the values they hold, resource reference types are
+// replaced with resource references, text types are replaced with their text form, and maps
+// with keys that are not strings, which are hidden from the mapper, are encoded with their
+// keys in text form.
+func encodeScalars(v any, src reflect.Value, path resource.PropertyPath, errs *[]error) any {
+	for src.IsValid() && (src.Kind() == reflect.Pointer || src.Kind() == reflect.Interface) {
+		if src.IsNil() {
+			return v
+		}
+		src = src.Elem()
+	}
+	if !src.IsValid() {
+		return v
+	}
+	t := src.Type()
+
+	if IsJSON(t) {
+		doc, err := unmarshalJSON(src)
+		if err != nil {
+			*errs = append(*errs, fieldError{path.String(), err.Error()})
+			return v
+		}
+		return doc
+	}
+	if IsReference(t) {
+		return encodeReference(src)
+	}
+	if IsText(t) {
+		if v == nil {
+			return v
+		}
+		text, err := formatText(src)
+		if err != nil {
+			*errs = append(*errs, fieldError{path.String(), err.Error()})
+			return v
+		}
+		return text
+	}
+	if _, ok := UnionVariants(t); ok {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		for i := range t.NumField() {
+			if inner := encodeScalars(m[unionKey(i)], src.Field(i), path, errs); inner != nil {
+				m[unionKey(i)] = inner
+			}
+		}
+		return v
+	}
+	if _, ok := Unwrap(t); ok {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		if held := encodeScalars(m[WrappedSignature], src.Field(0), path, errs); held != nil {
+			m[WrappedSignature] = held
+		}
+		return v
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Slice:
+		if arr, ok := v.([]any); ok {
+			for i := range min(len(arr), src.Len()) {
+				arr[i] = encodeScalars(arr[i], src.Index(i), append(path, i), errs)
+			}
+		}
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return encodeKeyedMap(src, path, errs)
+		}
+		if m, ok := v.(map[string]any); ok {
+			for k, elem := range m {
+				key := reflect.ValueOf(k).Convert(t.Key())
+				m[k] = encodeScalars(elem, src.MapIndex(key), append(path, k), errs)
+			}
+		}
+	case reflect.Struct:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		for _, field := range reflect.VisibleFields(t) {
+			tag, err := introspect.ParseTag(field)
+			if err != nil || tag.Internal {
+				continue
+			}
+			// A field promoted through a nil embedded pointer has no value.
+			fv, err := src.FieldByIndexErr(field.Index)
+			if err != nil {
+				continue
+			}
+			// A hidden map is missing from m, so fields are visited even when they are
+			// missing.
+			elem := encodeScalars(m[tag.Name], fv, append(path, tag.Name), errs)
+			ft := field.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			switch {
+			case elem != nil:
+				m[tag.Name] = elem
+			case IsJSON(ft) || IsReference(ft):
+				// An empty document or reference is omitted, like a nil slice.
+				delete(m, tag.Name)
+			}
+		}
+	}
+	return v
+}
@@ -0,0 +1,69 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ende
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// IsText reports if values of t are represented as strings.
+//
+// A time.Duration is written in the format of [time.Duration.String], such as "1h30m". Any
+// other type that implements both [encoding.TextMarshaler] and [encoding.TextUnmarshaler],
+// such as time.Time (RFC 3339) and net.IP, is written as its text form.
+func IsText(t reflect.Type) bool {
+	if t == durationType {
+		return true
+	}
+	ptr := reflect.PointerTo(t)
+	return ptr.Implements(textMarshalerType) && ptr.Implements(textUnmarshalerType)
+}
+
+// parseText parses s as a value of the text type t.
+func parseText(s string, t reflect.Type) (any, error) {
+	if t == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q: expected a value such as \"1h30m\"", s)
+		}
+		return d, nil
+	}
+	value := reflect.New(t)
+	if err := value.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", t, s, err)
+	}
+	return value.Elem().Interface(), nil
+}
+
+// formatText returns the text form of v, a value of a text type.
+func formatText(v reflect.Value) (string, error) {
+	if v.Type() == durationType {
+		return v.Interface().(time.Duration).String(), nil
+	}
+	// Use a pointer, since MarshalText may have a pointer receiver.
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	text, err := ptr.Interface().(encoding.TextMarshaler).MarshalText()
+	return string(text), err
+}
@@ -0,0 +1,143 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ende
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	r "github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/mapper"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsText(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsText(reflect.TypeFor[time.Duration]()))
+	assert.True(t, IsText(reflect.TypeFor[time.Time]()))
+	assert.True(t, IsText(reflect.TypeFor[net.IP]()))
+	assert.False(t, IsText(reflect.TypeFor[int64]()))
+	assert.False(t, IsText(reflect.TypeFor[s3Source]()))
+}
+
+func TestText(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		Timeout  time.Duration              `pulumi:"timeout"`
+		At       time.Time                  `pulumi:"at"`
+		Address  *net.IP                    `pulumi:"address,optional"`
+		Backoffs []time.Duration            `pulumi:"backoffs,optional"`
+		Hosts    map[string]net.IP          `pulumi:"hosts,optional"`
+		Window   union2[time.Time, float64] `pulumi:"window,optional"`
+	}
+
+	t.Run("roundtrip", func(t *testing.T) {
+		t.Parallel()
+
+		m := property.NewMap(map[string]property.Value{
+			"timeout":  property.New("1h30m0s"),
+			"at":       property.New("2024-05-01T12:00:00Z").WithSecret(true),
+			"address":  property.New("10.0.0.1"),
+			"backoffs": property.New([]property.Value{property.New("1s"), property.New("1m0s")}),
+			"hosts": property.New(map[string]property.Value{
+				"db": property.New("::1"),
+			}),
+			"window": property.New("2024-05-02T00:00:00Z"),
+		})
+
+		enc, v, err := Decode[args](m)
+		require.NoError(t, err)
+		address := net.ParseIP("10.0.0.1")
+		assert.Equal(t, args{
+			Timeout:  90 * time.Minute,
+			At:       time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Address:  &address,
+			Backoffs: []time.Duration{time.Second, time.Minute},
+			Hosts:    map[string]net.IP{"db": net.ParseIP("::1")},
+			Window:   union2[time.Time, float64]{A: ref(time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC))},
+		}, v)
+
+		actual, err := enc.Encode(v)
+		require.NoError(t, err)
+		assert.Equal(t, m, r.FromResourcePropertyValue(r.NewProperty(actual)).AsMap())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		m := property.NewMap(map[string]property.Value{
+			"timeout": property.New(property.Computed),
+			"at":      property.New(property.Computed),
+		})
+
+		enc, v, err := Decode[args](m)
+		require.NoError(t, err)
+		assert.Equal(t, args{}, v)
+
+		actual, err := enc.Encode(v)
+		require.NoError(t, err)
+		assert.Equal(t, m, r.FromResourcePropertyValue(r.NewProperty(actual)).AsMap())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := Decode[args](property.NewMap(map[string]property.Value{
+			"timeout":  property.New("soon"),
+			"at":       property.New("2024-05-01T12:00:00Z"),
+			"backoffs": property.New([]property.Value{property.New("1s"), property.New("1 minute")}),
+			"address":  property.New("localhost"),
+		}))
+		require.Error(t, err)
+
+		reasons := map[string]string{}
+		for _, failure := range err.Failures() {
+			failure := failure.(mapper.FieldError)
+			reasons[failure.Field()] = failure.Reason()
+		}
+		assert.Equal(t, map[string]string{
+			"timeout":     `invalid duration "soon": expected a value such as "1h30m"`,
+			"backoffs[1]": `invalid duration "1 minute": expected a value such as "1h30m"`,
+			"address":     `invalid net.IP "localhost": invalid IP address: localhost`,
+		}, reasons)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := Decode[args](property.NewMap(map[string]property.Value{
+			"timeout": property.New(""),
+			"at":      property.New(""),
+		}))
+		require.Error(t, err)
+
+		reasons := map[string]string{}
+		for _, failure := range err.Failures() {
+			failure := failure.(mapper.FieldError)
+			reasons[failure.Field()] = failure.Reason()
+		}
+		assert.Equal(t, map[string]string{
+			"timeout": `invalid duration "": expected a value such as "1h30m"`,
+			"at": `invalid time.Time "": ` +
+				`parsing time "" as "2006-01-02T15:04:05Z07:00": cannot parse "" as "2006"`,
+		}, reasons)
+	})
+}
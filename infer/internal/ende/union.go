@@ -0,0 +1,251 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ende
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/mapper"
+
+	"github.com/pulumi/pulumi-go-provider/internal/introspect"
+	"github.com/pulumi/pulumi-go-provider/internal/putil"
+)
+
+// UnionSignature prefixes the keys of the variants of a union type, such as
+// infer.Union2. The key of the i-th variant is UnionSignature followed by i.
+const UnionSignature = "9c4bd6e1f05a2e7d3b8c61a4f2e90d57"
+
+// UnionVariants returns the types of the variants of the union type t.
+//
+// Union types are recognized by their shape: a struct of pointers, where the i-th field
+// is tagged with [UnionSignature] followed by i.
+func UnionVariants(t reflect.Type) ([]reflect.Type, bool) {
+	if t == nil || t.Kind() != reflect.Struct || t.NumField() < 2 {
+		return nil, false
+	}
+	variants := make([]reflect.Type, t.NumField())
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("pulumi"), ",")
+		if name != unionKey(i) || f.Type.Kind() != reflect.Pointer {
+			return nil, false
+		}
+		variants[i] = f.Type.Elem()
+	}
+	return variants, true
+}
+
+func unionKey(i int) string { return UnionSignature + strconv.Itoa(i) }
+
+// discriminated is implemented by variants of a union type that are identified by the
+// value of one of their properties. It matches infer.UnionDiscriminator.
+type discriminated interface {
+	UnionDiscriminator() (property, value string)
+}
+
+// Discriminator returns the property that identifies the variants of a union, and the
+// value of that property for each variant.
+//
+// A union has a discriminator only if all of its variants declare the same discriminating
+// property.
+func Discriminator(variants []reflect.Type) (string, []string, bool) {
+	var property string
+	values := make([]string, len(variants))
+	for i, t := range variants {
+		d, ok := reflect.New(t).Interface().(discriminated)
+		if !ok {
+			return "", nil, false
+		}
+		p, v := d.UnionDiscriminator()
+		if i > 0 && p != property {
+			return "", nil, false
+		}
+		property, values[i] = p, v
+	}
+	return property, values, true
+}
+
+// walkUnion moves v under the key of the variant of the union typ that it matches.
+//
+// If v does not match exactly one variant, an error is recorded and the union is left
+// empty.
+func (e *ende) walkUnion(
+	v resource.PropertyValue, path resource.PropertyPath,
+	variants []reflect.Type, alignTypes bool,
+) resource.PropertyValue {
+	empty := resource.NewObjectProperty(resource.PropertyMap{})
+	// A value that is missing or unknown cannot choose a variant. An empty union is not
+	// encoded, so the place of an unknown value is kept for the encoder to restore.
+	if alignTypes {
+		e.mark(change{path: path, emptyAction: isOmitted})
+		return empty
+	}
+	if v.IsNull() {
+		return empty
+	}
+
+	i, ok := e.unionVariant(v, path, variants)
+	if !ok {
+		return empty
+	}
+	return resource.NewObjectProperty(resource.PropertyMap{
+		resource.PropertyKey(unionKey(i)): e.walk(v, path, variants[i], alignTypes),
+	})
+}
+
+// unionVariant returns the index of the variant that v matches.
+func (e *ende) unionVariant(
+	v resource.PropertyValue, path resource.PropertyPath, variants []reflect.Type,
+) (int, bool) {
+	fail := func(format string, a ...any) (int, bool) {
+		e.errs = append(e.errs, fieldError{path.String(), fmt.Sprintf(format, a...)})
+		return 0, false
+	}
+
+	if property, values, ok := Discriminator(variants); ok {
+		if !v.IsObject() {
+			return fail("expected an object with a %q property", property)
+		}
+		d, ok := v.ObjectValue()[resource.PropertyKey(property)]
+		if !ok {
+			return fail("missing %q, which must be one of %s", property, quoteAll(values))
+		}
+		d = putil.MakePublic(d)
+		if putil.IsComputed(d) {
+			// The variant will be known once the value is known.
+			return 0, false
+		}
+		for i, value := range values {
+			if d.IsString() && d.StringValue() == value {
+				return i, true
+			}
+		}
+		return fail("%q must be one of %s, found %v", property, quoteAll(values), d.Mappable())
+	}
+
+	var matches []int
+	for i, t := range variants {
+		trial := new(ende)
+		w := trial.walk(v, path, t, false)
+		mappable := trial.decodeScalars(w.Mappable(), path, t)
+		if len(trial.errs) > 0 {
+			continue
+		}
+		target := reflect.New(t).Interface()
+		if err := mapper.New(nil).DecodeValue(
+			map[string]any{"v": mappable}, t, "v", target, false); err == nil {
+			matches = append(matches, i)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], true
+	case 0:
+		return fail("value does not match any of %s", typeNames(variants))
+	default:
+		matched := make([]reflect.Type, len(matches))
+		for j, i := range matches {
+			matched[j] = variants[i]
+		}
+		return fail("value is ambiguous: it matches each of %s", typeNames(matched))
+	}
+}
+
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func typeNames(types []reflect.Type) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// flattenUnions replaces encoded union values in v, which was encoded from a value of type
+// t, with the value of their variant.
+//
+// An empty union flattens to nil, and is removed from the object that holds it.
+func flattenUnions(v any, t reflect.Type) any {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || v == nil {
+		return v
+	}
+
+	if variants, ok := UnionVariants(t); ok {
+		m, _ := v.(map[string]any)
+		for i, variant := range variants {
+			if inner, ok := m[unionKey(i)]; ok {
+				return flattenUnions(inner, variant)
+			}
+		}
+		return nil
+	}
+	if inner, ok := Unwrap(t); ok {
+		if m, ok := v.(map[string]any); ok {
+			if held, ok := m[WrappedSignature]; ok {
+				m[WrappedSignature] = flattenUnions(held, inner)
+			}
+		}
+		return v
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Slice:
+		if arr, ok := v.([]any); ok {
+			for i, elem := range arr {
+				arr[i] = flattenUnions(elem, t.Elem())
+			}
+		}
+	case reflect.Map:
+		if m, ok := v.(map[string]any); ok {
+			for k, elem := range m {
+				m[k] = flattenUnions(elem, t.Elem())
+			}
+		}
+	case reflect.Struct:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		for _, field := range reflect.VisibleFields(t) {
+			tag, err := introspect.ParseTag(field)
+			if err != nil || tag.Internal {
+				continue
+			}
+			elem, ok := m[tag.Name]
+			if !ok || elem == nil {
+				continue
+			}
+			if flat := flattenUnions(elem, field.Type); flat != nil {
+				m[tag.Name] = flat
+			} else {
+				delete(m, tag.Name)
+			}
+		}
+	}
+	return v
+}
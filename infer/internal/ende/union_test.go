@@ -0,0 +1,192 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ende
+
+import (
+	"reflect"
+	"testing"
+
+	r "github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/mapper"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// union2 has the shape of infer.Union2.
+type union2[A, B any] struct {
+	A *A `pulumi:"9c4bd6e1f05a2e7d3b8c61a4f2e90d570,optional"`
+	B *B `pulumi:"9c4bd6e1f05a2e7d3b8c61a4f2e90d571,optional"`
+}
+
+type s3Source struct {
+	Bucket string `pulumi:"bucket"`
+}
+
+type gitSource struct {
+	Repo string  `pulumi:"repo"`
+	Ref  *string `pulumi:"ref,optional"`
+}
+
+type taggedS3 struct {
+	Type string  `pulumi:"type"`
+	Path *string `pulumi:"path,optional"`
+}
+
+func (taggedS3) UnionDiscriminator() (string, string) { return "type", "s3" }
+
+type taggedGit struct {
+	Type string  `pulumi:"type"`
+	Path *string `pulumi:"path,optional"`
+}
+
+func (taggedGit) UnionDiscriminator() (string, string) { return "type", "git" }
+
+func ref[T any](v T) *T { return &v }
+
+func TestUnionVariants(t *testing.T) {
+	t.Parallel()
+
+	variants, ok := UnionVariants(reflect.TypeFor[union2[string, s3Source]]())
+	assert.True(t, ok)
+	assert.Equal(t, []reflect.Type{reflect.TypeFor[string](), reflect.TypeFor[s3Source]()}, variants)
+
+	_, ok = UnionVariants(reflect.TypeFor[s3Source]())
+	assert.False(t, ok)
+}
+
+func TestUnion(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		Source  union2[s3Source, gitSource]     `pulumi:"source"`
+		Sources []union2[string, float64]       `pulumi:"sources,optional"`
+		Tagged  *union2[taggedS3, taggedGit]    `pulumi:"tagged,optional"`
+		Other   union2[map[string]string, bool] `pulumi:"other,optional"`
+	}
+
+	decode := func(t *testing.T, m map[string]property.Value) (Encoder, args, mapper.MappingError) {
+		return Decode[args](property.NewMap(m))
+	}
+
+	t.Run("roundtrip", func(t *testing.T) {
+		t.Parallel()
+
+		m := property.NewMap(map[string]property.Value{
+			"source": property.New(map[string]property.Value{
+				"repo": property.New("github.com/pulumi/pulumi").WithSecret(true),
+			}),
+			"sources": property.New([]property.Value{property.New("a"), property.New(1.0)}),
+			"tagged": property.New(map[string]property.Value{
+				"type": property.New("git"),
+			}),
+		})
+
+		enc, v, err := Decode[args](m)
+		require.NoError(t, err)
+		assert.Equal(t, args{
+			Source: union2[s3Source, gitSource]{B: &gitSource{Repo: "github.com/pulumi/pulumi"}},
+			Sources: []union2[string, float64]{
+				{A: ref("a")},
+				{B: ref(1.0)},
+			},
+			Tagged: &union2[taggedS3, taggedGit]{B: &taggedGit{Type: "git"}},
+		}, v)
+
+		actual, err := enc.Encode(v)
+		require.NoError(t, err)
+		assert.Equal(t, m, r.FromResourcePropertyValue(r.NewProperty(actual)).AsMap())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		enc, v, err := decode(t, map[string]property.Value{
+			"source": property.New(property.Computed),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, args{}, v)
+
+		actual, err := enc.Encode(v)
+		require.NoError(t, err)
+		assert.Equal(t, property.NewMap(map[string]property.Value{
+			"source": property.New(property.Computed),
+		}), r.FromResourcePropertyValue(r.NewProperty(actual)).AsMap())
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := decode(t, map[string]property.Value{
+			"source": property.New(map[string]property.Value{
+				"url": property.New("https://example.com"),
+			}),
+		})
+		require.Error(t, err)
+		require.Len(t, err.Failures(), 1)
+		failure := err.Failures()[0].(mapper.FieldError)
+		assert.Equal(t, "source", failure.Field())
+		assert.Equal(t, "value does not match any of ende.s3Source, ende.gitSource", failure.Reason())
+	})
+
+	t.Run("unknown discriminator", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := decode(t, map[string]property.Value{
+			"source": property.New(map[string]property.Value{"bucket": property.New("b")}),
+			"tagged": property.New(map[string]property.Value{
+				"type": property.New("svn"),
+			}),
+		})
+		require.Error(t, err)
+		require.Len(t, err.Failures(), 1)
+		failure := err.Failures()[0].(mapper.FieldError)
+		assert.Equal(t, "tagged", failure.Field())
+		assert.Equal(t, `"type" must be one of "s3", "git", found svn`, failure.Reason())
+	})
+
+	t.Run("discriminator", func(t *testing.T) {
+		t.Parallel()
+
+		// Both variants have the same shape, so only the discriminator tells them apart.
+		_, v, err := decode(t, map[string]property.Value{
+			"source": property.New(map[string]property.Value{"bucket": property.New("b")}),
+			"tagged": property.New(map[string]property.Value{
+				"type": property.New("s3"),
+				"path": property.New("/"),
+			}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &union2[taggedS3, taggedGit]{A: &taggedS3{Type: "s3", Path: ref("/")}}, v.Tagged)
+	})
+}
+
+func TestUnionAmbiguous(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		Value union2[map[string]string, map[string]float64] `pulumi:"value"`
+	}
+
+	_, _, err := Decode[args](property.NewMap(map[string]property.Value{
+		"value": property.New(map[string]property.Value{}),
+	}))
+	require.Error(t, err)
+	require.Len(t, err.Failures(), 1)
+	failure := err.Failures()[0].(mapper.FieldError)
+	assert.Equal(t, "value", failure.Field())
+	assert.Equal(t, "value is ambiguous: it matches each of map[string]string, map[string]float64",
+		failure.Reason())
+}
@@ -0,0 +1,142 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+
+	"github.com/pulumi/pulumi-go-provider/internal/introspect"
+)
+
+// CustomPreview describes a resource that computes its outputs during preview without
+// creating or updating anything.
+//
+// If a resource implements CustomPreview, Preview is called instead of Create and Update
+// when they are previewed, so Create and Update are only called to make changes and do
+// not need to check DryRun.
+//
+// The output fields that Preview sets to a non-zero value are known in the preview. The
+// fields it leaves as their zero value are unknown, unless they are marked with
+// [PreviewResponse.SetKnown] or [OutputField.AlwaysKnown]. Secretness still flows from inputs to outputs as
+// described by [ExplicitDependencies], but Preview is responsible for not setting fields
+// computed from unknown inputs (see [PreviewRequest.IsUnknown]).
+//
+// Example:
+//
+//	func (*Bucket) Preview(
+//		ctx context.Context, req infer.PreviewRequest[BucketArgs, BucketState],
+//	) (infer.PreviewResponse[BucketState], error) {
+//		// The ARN of a bucket is derived from its name, so it is known before the
+//		// bucket is created.
+//		state := BucketState{BucketArgs: req.Inputs}
+//		if !req.IsUnknown(&req.Inputs.Name) {
+//			state.Arn = "arn:aws:s3:::" + req.Inputs.Name
+//		}
+//		return infer.PreviewResponse[BucketState]{Output: state}, nil
+//	}
+type CustomPreview[I, O any] interface {
+	Preview(ctx context.Context, req PreviewRequest[I, O]) (PreviewResponse[O], error)
+}
+
+// PreviewRequest contains all the parameters for a Preview operation.
+type PreviewRequest[I, O any] struct {
+	// The resource name.
+	Name string
+	// The resource ID. It is empty if the resource is being created.
+	ID string
+	// The resource inputs.
+	Inputs I
+	// The old resource state. It is the zero value if the resource is being created.
+	State O
+	// Whether the resource is being created, as opposed to updated.
+	IsCreate bool
+
+	unknowns unknownFields
+}
+
+// IsUnknown reports whether field was unknown when the request was sent to the provider.
+//
+// field must be a pointer to a field of req.Inputs, or a pointer to req.Inputs itself.
+// See [CreateRequest.IsUnknown] for details.
+func (r *PreviewRequest[I, O]) IsUnknown(field any) bool {
+	return r.unknowns.has(&r.Inputs, field)
+}
+
+// PreviewResponse contains all the results from a Preview operation.
+type PreviewResponse[O any] struct {
+	// The resource ID, if it is known before the resource is created.
+	ID string
+	// The previewed outputs of the resource. Fields with their zero value are unknown,
+	// unless they are marked with [PreviewResponse.SetKnown].
+	Output O
+
+	known map[string]struct{}
+}
+
+// SetKnown marks fields of r.Output as known, even if they hold their zero value:
+//
+//	resp.Output.Public = false
+//	resp.SetKnown(&resp.Output.Public)
+//
+// Each field must be a pointer to a field of r.Output.
+func (r *PreviewResponse[O]) SetKnown(fields ...any) {
+	matcher := introspect.NewFieldMatcher(&r.Output)
+	for _, field := range fields {
+		tag, ok, err := matcher.GetField(field)
+		contract.Assertf(ok, "SetKnown: %v is not a field of the response output", field)
+		contract.AssertNoErrorf(err, "SetKnown: invalid field")
+		if r.known == nil {
+			r.known = map[string]struct{}{}
+		}
+		r.known[tag.Name] = struct{}{}
+	}
+}
+
+// markPreviewed marks the fields of output that were set by [CustomPreview.Preview] as
+// known, and the fields it left unset as unknown. Fields in known were set explicitly,
+// so they are known even if they hold their zero value.
+func (g *fieldGenerator) markPreviewed(output any, known map[string]struct{}) {
+	v := reflect.ValueOf(output)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		tag, err := introspect.ParseTag(f)
+		if err != nil || tag.Internal {
+			continue
+		}
+		field := g.getField(tag.Name)
+		if _, ok := known[tag.Name]; ok {
+			field.known = true
+			continue
+		}
+		// A field promoted through a nil embedded pointer was not set.
+		if fv, err := v.FieldByIndexErr(f.Index); err != nil || fv.IsZero() {
+			field.previewUnknown = true
+		} else {
+			field.known = true
+		}
+	}
+}
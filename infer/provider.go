@@ -17,6 +17,7 @@ package infer
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	p "github.com/pulumi/pulumi-go-provider"
 	t "github.com/pulumi/pulumi-go-provider/middleware"
@@ -80,10 +81,36 @@ type Options struct {
 	// `pkg:fizz:Buzz`.
 	ModuleMap map[tokens.ModuleName]tokens.ModuleName
 
+	// TokenNamer names the tokens that are derived from Go types, in place of
+	// [DefaultTokenNamer].
+	//
+	// For example, setting
+	//
+	//	`opts.TokenNamer = infer.TrimTokenNamer(nil, "", "Resource")`
+	//
+	// exposes the resource `storage.BucketResource` at `pkg:storage:Bucket`. ModuleMap is
+	// applied to the tokens that TokenNamer returns.
+	TokenNamer TokenNamer
+
 	// wrapped is an optional provider which this new provider wraps.
 	wrapped p.Provider
 }
 
+// withTokenNamer returns o with o.TokenNamer set on each resource, component, function and
+// config, so that they name the tokens they derive from Go types with it.
+func (o Options) withTokenNamer() Options {
+	if o.TokenNamer == nil {
+		return o
+	}
+	o.Resources = nameTokens(o.Resources, o.TokenNamer)
+	o.Components = nameTokens(o.Components, o.TokenNamer)
+	o.Functions = nameTokens(o.Functions, o.TokenNamer)
+	if o.Config != nil {
+		o.Config = o.Config.withTokenNamer(o.TokenNamer)
+	}
+	return o
+}
+
 func (o Options) dispatch() dispatch.Options {
 	functions := map[tokens.Type]t.Invoke{}
 	for _, r := range o.Functions {
@@ -146,8 +173,16 @@ func Provider(opts Options) p.Provider {
 // The resulting provider will respond to resources and functions that are described in `opts`, delegating
 // unknown calls to the underlying provider.
 func Wrap(provider p.Provider, opts Options) p.Provider {
+	opts = opts.withTokenNamer()
 	provider = dispatch.Wrap(provider, opts.dispatch())
 	provider = schema.Wrap(provider, opts.schema())
+	if slices.ContainsFunc(opts.Resources, InferredResource.hasResourceRef) ||
+		slices.ContainsFunc(opts.Functions, InferredFunction.hasResourceRef) {
+		provider = recordResourceStates(provider)
+	}
+	if slices.ContainsFunc(opts.Resources, InferredResource.isAutoNamed) {
+		provider.SupportsAutonamingConfiguration = true
+	}
 
 	config := opts.Config
 	if config != nil {
@@ -30,6 +30,7 @@ type ProviderBuilder struct {
 	functions  []InferredFunction
 	config     InferredConfig
 	moduleMap  map[tokens.ModuleName]tokens.ModuleName
+	tokenNamer TokenNamer
 	wrapped    provider.Provider
 }
 
@@ -128,6 +129,14 @@ func (pb *ProviderBuilder) WithModuleMap(moduleMap map[tokens.ModuleName]tokens.
 	return pb
 }
 
+// WithTokenNamer sets the strategy used to name the tokens of the provider's resources,
+// components, functions and types, such as [PackagePathTokenNamer], [FixedModuleTokenNamer]
+// or [TrimTokenNamer].
+func (pb *ProviderBuilder) WithTokenNamer(namer TokenNamer) *ProviderBuilder {
+	pb.tokenNamer = namer
+	return pb
+}
+
 // WithLanguageMap sets the language map in the provider's metadata.
 // The language map is a mapping of language names to language-specific metadata.
 // This is used to customize how the provider is exposed in different languages.
@@ -269,6 +278,7 @@ func (pb *ProviderBuilder) BuildOptions() Options {
 		Functions:  pb.functions,
 		Config:     pb.config,
 		ModuleMap:  pb.moduleMap,
+		TokenNamer: pb.tokenNamer,
 		wrapped:    pb.wrapped,
 	}
 }
@@ -279,6 +289,30 @@ func (pb *ProviderBuilder) validate() error {
 		return fmt.Errorf("at least one resource, component, or function is required")
 	}
 
+	// Check that the token namer gives every element a valid token, since the provider
+	// cannot dispatch to elements without one.
+	opts := Options{
+		Resources:  pb.resources,
+		Components: pb.components,
+		Functions:  pb.functions,
+		TokenNamer: pb.tokenNamer,
+	}.withTokenNamer()
+	var elements []interface{ GetToken() (tokens.Type, error) }
+	for _, r := range opts.Resources {
+		elements = append(elements, r)
+	}
+	for _, c := range opts.Components {
+		elements = append(elements, c)
+	}
+	for _, f := range opts.Functions {
+		elements = append(elements, f)
+	}
+	for _, e := range elements {
+		if _, err := e.GetToken(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,105 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	crand "crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"math/rand/v2"
+)
+
+const (
+	suffixChars   = "abcdefghijklmnopqrstuvwxyz0123456789"
+	passwordChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!#$%&*()-_=+[]{}<>:?"
+)
+
+// Random generates random values that are stable for a resource.
+//
+// A Random created from the random seed of a [CheckRequest] generates the same values
+// each time Check is called for the same resource, across previews and updates. The
+// engine only changes the seed when the resource is replaced.
+//
+// Values are generated from a stream, so the values generated depend on the order of
+// calls. Values for different purposes should be generated from different Randoms,
+// created with different purposes:
+//
+//	func (*User) Check(
+//		ctx context.Context, req infer.CheckRequest,
+//	) (infer.CheckResponse[UserArgs], error) {
+//		args, failures, err := infer.DefaultCheck[UserArgs](ctx, req.NewInputs)
+//		if err != nil || len(failures) > 0 {
+//			return infer.CheckResponse[UserArgs]{Inputs: args, Failures: failures}, err
+//		}
+//		if args.Password == "" {
+//			args.Password = req.Random("password").Password(24)
+//		}
+//		return infer.CheckResponse[UserArgs]{Inputs: args}, nil
+//	}
+type Random struct{ r *rand.Rand }
+
+// NewRandom creates a [Random] from seed, for values generated for purpose.
+//
+// If seed is empty, as it is for engines that do not send a random seed, the values
+// generated are not stable.
+func NewRandom(seed []byte, purpose string) *Random {
+	if len(seed) == 0 {
+		seed = make([]byte, 32)
+		_, _ = crand.Read(seed)
+	}
+	h := sha256.New()
+	h.Write(seed)
+	h.Write([]byte(purpose))
+	var chachaSeed [32]byte
+	copy(chachaSeed[:], h.Sum(nil))
+	return &Random{rand.New(rand.NewChaCha8(chachaSeed))} //nolint:gosec // ChaCha8 is a secure source.
+}
+
+// Random creates a [Random] from the random seed of the request, for values generated
+// for purpose.
+func (r CheckRequest) Random(purpose string) *Random {
+	return NewRandom(r.RandomSeed, purpose)
+}
+
+// Suffix returns n random lowercase letters and digits, suitable for making a name
+// unique.
+func (r *Random) Suffix(n int) string {
+	return r.fromChars(suffixChars, n)
+}
+
+// Password returns a random password of the given length, made of letters, digits and
+// symbols.
+func (r *Random) Password(length int) string {
+	return r.fromChars(passwordChars, length)
+}
+
+// UUID returns a random version 4 UUID.
+func (r *Random) UUID() string {
+	var b [16]byte
+	for i := range b {
+		b[i] = byte(r.r.UintN(256))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // Version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // Variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func (r *Random) fromChars(chars string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = chars[r.r.IntN(len(chars))]
+	}
+	return string(b)
+}
@@ -0,0 +1,69 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandom(t *testing.T) {
+	t.Parallel()
+
+	seed := []byte("a seed from the engine")
+
+	t.Run("stable", func(t *testing.T) {
+		t.Parallel()
+
+		r1, r2 := NewRandom(seed, "name"), NewRandom(seed, "name")
+		assert.Equal(t, r1.Suffix(8), r2.Suffix(8))
+		assert.Equal(t, r1.Password(16), r2.Password(16))
+		assert.Equal(t, r1.UUID(), r2.UUID())
+
+		req := CheckRequest{RandomSeed: seed}
+		assert.Equal(t, NewRandom(seed, "name").Suffix(8), req.Random("name").Suffix(8))
+	})
+
+	t.Run("purpose", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NotEqual(t, NewRandom(seed, "a").Suffix(16), NewRandom(seed, "b").Suffix(16))
+		assert.NotEqual(t, NewRandom(seed, "a").Suffix(16), NewRandom([]byte("other"), "a").Suffix(16))
+	})
+
+	t.Run("no-seed", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NotEqual(t, NewRandom(nil, "a").Suffix(16), NewRandom(nil, "a").Suffix(16))
+	})
+
+	t.Run("format", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewRandom(seed, "format")
+		suffix := r.Suffix(32)
+		assert.Len(t, suffix, 32)
+		assert.Empty(t, strings.Trim(suffix, suffixChars))
+
+		password := r.Password(64)
+		assert.Len(t, password, 64)
+		assert.Empty(t, strings.Trim(password, passwordChars))
+
+		uuid := r.UUID()
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, uuid)
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
@@ -58,6 +59,8 @@ import (
 // - [CustomRead]
 // - [CustomDelete]
 // - [CustomStateMigrations]
+// - [CustomStateVersions]
+// - [CustomPreview]
 // - [Annotated]
 //
 // Example:
@@ -108,6 +111,28 @@ type CreateRequest[I any] struct {
 	Inputs I
 	// Whether this is a preview operation.
 	DryRun bool
+	// The time at which the operation will time out, or the zero time if the operation
+	// has no timeout.
+	Deadline time.Time
+
+	unknowns unknownFields
+}
+
+// IsUnknown reports whether field was unknown when the request was sent to the provider.
+//
+// field must be a pointer to req.Inputs or to a value within it, such as a field of a
+// nested struct or an element of a slice:
+//
+//	if req.IsUnknown(&req.Inputs.Field) {
+//		// Field holds its zero value, not the value that will be sent during the update.
+//	}
+//
+// Unknown inputs are only sent during a preview. They are decoded as the zero value of
+// their type, so IsUnknown is the only way to tell an unknown input apart from an empty
+// one. A field is considered unknown if any value nested within it is unknown. IsUnknown
+// reports false if field does not point into req.Inputs.
+func (r *CreateRequest[I]) IsUnknown(field any) bool {
+	return r.unknowns.has(&r.Inputs, field)
 }
 
 // CreateResponse contains all the results from a Create operation
@@ -130,6 +155,9 @@ type CheckRequest struct {
 	OldInputs property.Map
 	// The new resource inputs.
 	NewInputs property.Map
+	// A random seed that is stable for the resource until it is replaced. Use
+	// [CheckRequest.Random] to generate values from it.
+	RandomSeed []byte
 }
 
 // CheckResponse contains all the results from a Check operation
@@ -190,6 +218,19 @@ type UpdateRequest[I, O any] struct {
 	Inputs I
 	// Whether this is a preview operation.
 	DryRun bool
+	// The time at which the operation will time out, or the zero time if the operation
+	// has no timeout.
+	Deadline time.Time
+
+	unknowns unknownFields
+}
+
+// IsUnknown reports whether field was unknown when the request was sent to the provider.
+//
+// field must be a pointer to a field of req.Inputs, or a pointer to req.Inputs itself.
+// See [CreateRequest.IsUnknown] for details.
+func (r *UpdateRequest[I, O]) IsUnknown(field any) bool {
+	return r.unknowns.has(&r.Inputs, field)
 }
 
 // UpdateResponse contains all the results from an Update operation
@@ -240,6 +281,11 @@ type ReadResponse[I, O any] struct {
 // fit into I and O respectively. If they do, then the values will be returned as is.
 // Otherwise an error will be returned.
 //
+// Resources that implement CustomRead describe their outputs as the state inputs of their
+// schema, so that generated SDKs can look up existing resources with a static get
+// function. A resource that can only be looked up should be registered with
+// [ReadOnlyResource].
+//
 // Example:
 // TODO - Probably something to do with the file system.
 type CustomRead[I, O any] interface {
@@ -255,6 +301,9 @@ type DeleteRequest[O any] struct {
 	ID string
 	// The current resource state.
 	State O
+	// The time at which the operation will time out, or the zero time if the operation
+	// has no timeout.
+	Deadline time.Time
 }
 
 // DeleteResponse contains all the results from a Delete operation
@@ -352,6 +401,38 @@ type CustomStateMigrations[O any] interface {
 	StateMigrations(ctx context.Context) []StateMigrationFunc[O]
 }
 
+// CustomInputMigrations describes a resource whose inputs have changed shape, for
+// example because an input field was renamed.
+//
+// Old inputs are read back from state, so without a migration a renamed field shows up
+// as the removal of the old field and the addition of the new one. InputMigrations
+// upgrades old inputs to I before they are passed to [CustomCheck] and before they are
+// compared by Diff, so that a renamed field with an unchanged value shows no diff.
+//
+// Input migrations are created with [StateMigration]:
+//
+//	type MyArgsV1 struct {
+//		Size int `pulumi:"size"`
+//	}
+//
+//	func (*MyResource) InputMigrations(context.Context) []infer.StateMigrationFunc[MyArgs] {
+//		return []infer.StateMigrationFunc[MyArgs]{
+//			infer.StateMigration(func(_ context.Context, v1 MyArgsV1) (infer.MigrationResult[MyArgs], error) {
+//				return infer.MigrationResult[MyArgs]{Result: &MyArgs{SizeGb: v1.Size}}, nil
+//			}),
+//		}
+//	}
+//
+// A migrated input is secret if the old input of the same name was secret, or the old
+// input set under one of its aliases (see the `alias` option of the `provider` tag). The
+// secretness of other old inputs is not carried across a migration from a typed old shape.
+type CustomInputMigrations[I any] interface {
+	// InputMigrations is the list of known input migrations.
+	//
+	// The first migration to return a non-nil Result will be used.
+	InputMigrations(ctx context.Context) []StateMigrationFunc[I]
+}
+
 // Annotator is used as part of [Annotated] to describe schema metadata for a resource or
 // type.
 //
@@ -388,6 +469,25 @@ type Annotator interface {
 	//
 	SetToken(module tokens.ModuleName, name tokens.TypeName)
 
+	// Set how the instantiations of a generic type are named.
+	//
+	// By default, an instantiation is named after the generic type followed by "Of" and the
+	// names of its type arguments joined by "And", so Page[Item] is named PageOfItem and
+	// Pair[string, Item] is named PairOfStringAndItem. Type arguments from another package
+	// are prefixed with its name, so Page[other.Item] is named PageOfOtherItem. Otherwise,
+	// each instantiation is named fmt.Sprintf(format, args...), where args are the names of
+	// its type arguments.
+	//
+	// For example:
+	//
+	//	func (*Page[T]) Annotate(a infer.Annotator) {
+	//		a.SetGenericName("%sPage")
+	//	}
+	//
+	// This names Page[Item] ItemPage. The module is derived from the package of the generic
+	// type, unless it is set by SetToken, which names every instantiation the same.
+	SetGenericName(format string)
+
 	// Add a type [alias](https://www.pulumi.com/docs/using-pulumi/pulumi-packages/schema/#alias) for
 	// this resource.
 	//
@@ -409,6 +509,94 @@ type Annotator interface {
 	//		a.Deprecate(&s, "Struct is deprecated")
 	//	}
 	Deprecate(i any, message string)
+
+	// Set the default timeouts for creating, updating and deleting a resource.
+	//
+	// A default timeout applies when the program does not set the corresponding
+	// customTimeouts resource option. A zero duration means no default timeout for that
+	// operation.
+	//
+	// For example:
+	//
+	//	func (*MyResource) Annotate(a infer.Annotator) {
+	//		a.SetDefaultTimeouts(20*time.Minute, 20*time.Minute, 5*time.Minute)
+	//	}
+	SetDefaultTimeouts(create, update, delete time.Duration)
+
+	// Generate a value for a string or *string field when the program does not set it.
+	//
+	// The name is generated during Check from pattern, which may contain:
+	//
+	//	${name}          The name of the resource.
+	//	${hex(n)}        n random hexadecimal digits.
+	//	${alphanum(n)}   n random lowercase letters and digits.
+	//	${string(n)}     n random lowercase letters.
+	//	${num(n)}        n random digits.
+	//	${uuid}          A random UUID.
+	//
+	// An empty pattern means "${name}-${hex(7)}". If maxLength is positive, ${name} is
+	// truncated so that the generated name is at most maxLength long. Random values are
+	// generated from the seed sent by the engine, so the name is stable across previews.
+	//
+	// A generated name is kept when the resource is updated, and changing the field
+	// replaces the resource. If the stack configures autonaming, the name proposed by the
+	// engine is used instead.
+	//
+	// For example:
+	//
+	//	func (args *BucketArgs) Annotate(a infer.Annotator) {
+	//		a.SetAutoName(&args.Name, "${name}-${alphanum(6)}", 63)
+	//	}
+	//
+	// Auto-naming is applied by [DefaultCheck], so resources that implement [CustomCheck]
+	// must call [DefaultCheck] for it to take effect.
+	SetAutoName(field any, pattern string, maxLength int)
+
+	// Set the name of a struct field in the SDK of a language, such as to rename a field
+	// that clashes with a keyword of the language.
+	//
+	// For example:
+	//
+	//	func (args *RuleArgs) Annotate(a infer.Annotator) {
+	//		a.SetLanguageName(&args.Lambda, "python", "lambda_")
+	//	}
+	//
+	// This is the same as a.SetLanguage(&args.Lambda, "python", map[string]any{"name": "lambda_"}).
+	SetLanguageName(field any, lang, name string)
+
+	// Set the language specific information of a struct field, or of the resource, object
+	// or function when called on the struct itself, as described in the
+	// [schema](https://www.pulumi.com/docs/iac/using-pulumi/pulumi-packages/schema/#language-specific-extensions).
+	//
+	// info is written to the language section of the schema as JSON, and replaces any
+	// info previously set for lang. For example:
+	//
+	//	func (r *Record) Annotate(a infer.Annotator) {
+	//		a.SetLanguage(&r, "csharp", map[string]any{"name": "DnsRecord"})
+	//	}
+	SetLanguage(i any, lang string, info any)
+
+	// Add an example to the documentation of a resource or function.
+	//
+	// programs holds the source of the example in each language, keyed by "typescript",
+	// "python", "go", "csharp", "java" or "yaml". Examples are shown in the order they are
+	// added, after the description. For example:
+	//
+	//	func (*Bucket) Annotate(a infer.Annotator) {
+	//		a.AddExample("A private bucket", map[string]string{
+	//			"typescript": `new storage.Bucket("b", { acl: "private" });`,
+	//			"yaml": `resources:
+	//	  b:
+	//	    type: storage:index:Bucket
+	//	    properties:
+	//	      acl: private`,
+	//		})
+	//	}
+	//
+	// The properties set by resources and functions of the provider in YAML programs are
+	// checked against the schema when it is generated. Only the YAML program of an example
+	// is checked: programs in other languages are added to the schema as they are.
+	AddExample(title string, programs map[string]string)
 }
 
 // Annotated is used to describe the fields of an object or a resource. Annotated can be
@@ -453,6 +641,11 @@ func (*fieldGenerator) isFieldSelector() {}
 //
 // If ExplicitDependencies is not implemented, it is assumed that all outputs depend on
 // all inputs.
+//
+// The wiring only decides which outputs are secret or computed. It does not narrow the
+// resource dependencies of outputs: the engine does not send the dependencies of inputs
+// to Create and Update for custom resources, so consumers of any output depend on the
+// whole resource.
 type ExplicitDependencies[I, O any] interface {
 	// WireDependencies specifies the dependencies between inputs and outputs.
 	WireDependencies(f FieldSelector, args *I, state *O)
@@ -470,6 +663,9 @@ type OutputField interface {
 	// or preview.
 	AlwaysKnown()
 	// Specify that a state (output) Field uses data from some args (input) Fields.
+	//
+	// The field inherits the secretness and computedness of its dependencies, but not
+	// their resource dependencies (see [ExplicitDependencies]).
 	DependsOn(dependencies ...InputField)
 
 	// Seal the interface.
@@ -518,6 +714,8 @@ type field struct {
 
 	// If the output is known, regardless of other factors.
 	known bool
+	// If the output is unknown during preview, because [CustomPreview] did not set it.
+	previewUnknown bool
 }
 
 type dependency struct {
@@ -633,7 +831,9 @@ func markField(
 	oldInputs, inputs resource.PropertyMap, isCreate, isPreview bool,
 ) resource.PropertyValue {
 	// Fields can only be computed during preview. They must be known by when the resource is actually created.
-	if isPreview {
+	if isPreview && field.previewUnknown && !field.known && !putil.IsComputed(prop) {
+		prop = putil.MakeComputed(prop)
+	} else if isPreview {
 		prop = markComputed(field, key, prop, oldInputs, inputs, isCreate)
 	}
 
@@ -926,6 +1126,11 @@ type InferredResource interface {
 	schema.Resource
 
 	isInferredResource()
+	// isAutoNamed reports if the resource has fields annotated with [Annotator.SetAutoName].
+	isAutoNamed() bool
+	withTokenNamer(TokenNamer) InferredResource
+	// hasResourceRef reports if the inputs or outputs of the resource hold a [ResourceRef].
+	hasResourceRef() bool
 }
 
 // Resource creates a new InferredResource, where `R` is the resource controller, `I` is
@@ -934,42 +1139,86 @@ func Resource[R CustomResource[I, O], I, O any](rsc R) InferredResource {
 	return &derivedResourceController[R, I, O]{receiver: &rsc}
 }
 
-type derivedResourceController[R CustomResource[I, O], I, O any] struct {
+// ReadOnlyResource creates a new InferredResource for a resource that is only ever read,
+// never created. `R` is the resource controller, `I` is the resources inputs and `O` is
+// the resources outputs.
+//
+// Read-only resources are looked up with the static get function of the generated SDKs.
+// Creating one fails.
+func ReadOnlyResource[R CustomRead[I, O], I, O any](rsc R) InferredResource {
+	return &derivedResourceController[R, I, O]{receiver: &rsc}
+}
+
+// R implements [CustomResource], or [CustomRead] if it is read-only.
+type derivedResourceController[R, I, O any] struct {
 	receiver *R
+	namer    TokenNamer
 }
 
 func (*derivedResourceController[R, I, O]) isInferredResource() {}
 
+func (rc derivedResourceController[R, I, O]) withTokenNamer(namer TokenNamer) InferredResource {
+	rc.namer = namer
+	return &rc
+}
+
+func (*derivedResourceController[R, I, O]) isAutoNamed() bool {
+	return len(getAnnotated(reflect.TypeFor[I]()).AutoNames) > 0
+}
+
+func (*derivedResourceController[R, I, O]) hasResourceRef() bool {
+	return hasResourceRef[I]() || hasResourceRef[O]()
+}
+
 func (rc *derivedResourceController[R, I, O]) GetSchema(reg schema.RegisterDerivativeType) (
 	pschema.ResourceSpec, error,
 ) {
-	if err := registerTypes[I](reg); err != nil {
+	if err := registerTypes[I](reg, rc.namer); err != nil {
 		return pschema.ResourceSpec{}, err
 	}
-	if err := registerTypes[O](reg); err != nil {
+	if err := registerTypes[O](reg, rc.namer); err != nil {
 		return pschema.ResourceSpec{}, err
 	}
-	r, errs := getResourceSchema[R, I, O](false)
+	r, errs := getResourceSchema[R, I, O](false, rc.namer)
+
+	// Resources that can read their state from the provider are looked up by the static
+	// get function of the generated SDKs, which takes any of the resource's outputs.
+	if _, ok := any(*rc.receiver).(CustomRead[I, O]); ok {
+		r.StateInputs = &pschema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "Input properties used for looking up and filtering resources.",
+			Properties:  r.Properties,
+		}
+	}
 	return r, errs.ErrorOrNil()
 }
 
-func getToken[R any](transform func(tokens.Type) tokens.Type) (tokens.Type, error) {
+func getToken[R any](namer TokenNamer, transform func(tokens.Type) tokens.Type) (tokens.Type, error) {
 	var r R
-	return getTokenOf(reflect.TypeOf(r), transform)
+	return getTokenOf(reflect.TypeOf(r), namer, transform)
 }
 
-func getTokenOf(t reflect.Type, transform func(tokens.Type) tokens.Type) (tokens.Type, error) {
+// getTokenOf returns the token of t. Unless t sets its token with [Annotator.SetToken], the
+// token is named by namer, or by [DefaultTokenNamer] if namer is nil.
+func getTokenOf(t reflect.Type, namer TokenNamer, transform func(tokens.Type) tokens.Type) (tokens.Type, error) {
 	annotator := getAnnotated(t)
 	if annotator.Token != "" {
 		return tokens.Type(annotator.Token), nil
 	}
 
-	tk, err := introspect.GetToken("pkg", t)
-	if transform == nil || err != nil {
-		return tk, err
+	tk, err := introspect.GetGenericToken("pkg", t, annotator.GenericFormat)
+	if err != nil {
+		return "", err
 	}
-
-	return transform(tk), nil
+	if namer != nil {
+		if tk, err = namer.token(tk.Package(), t); err != nil {
+			return "", err
+		}
+	}
+	if transform != nil {
+		tk = transform(tk)
+	}
+	return tk, nil
 }
 
 func (rc *derivedResourceController[R, I, O]) GetToken() (tokens.Type, error) {
@@ -983,7 +1232,7 @@ func (rc *derivedResourceController[R, I, O]) GetToken() (tokens.Type, error) {
 			return tokens.Type(a.Token), nil
 		}
 	}
-	return getToken[R](nil)
+	return getToken[R](rc.namer, nil)
 }
 
 func (rc *derivedResourceController[R, I, O]) getInstance() *R {
@@ -996,7 +1245,12 @@ func (rc *derivedResourceController[R, I, O]) Check(ctx context.Context, req p.C
 		//
 		// We do not apply defaults if the user has implemented Check
 		// themselves. Defaults are applied by [DefaultCheck].
-		encoder, i, failures, err := callCustomCheck(ctx, r, req.Urn.Name(), req.State, req.Inputs)
+		olds, err := migrateInputs[R, I](ctx, req.State)
+		if err != nil {
+			return p.CheckResponse{}, err
+		}
+		ctx = withCheckContext(ctx, req, olds)
+		encoder, i, failures, err := callCustomCheck(ctx, r, req.Urn.Name(), olds, req.Inputs, req.RandomSeed)
 		if err != nil {
 			return p.CheckResponse{}, err
 		}
@@ -1039,8 +1293,19 @@ func (rc *derivedResourceController[R, I, O]) Check(ctx context.Context, req p.C
 	if i, err = defaultCheck(i); err != nil {
 		return p.CheckResponse{}, fmt.Errorf("unable to apply defaults: %w", err)
 	}
+	olds, err := migrateInputs[R, I](ctx, req.State)
+	if err != nil {
+		return p.CheckResponse{}, err
+	}
+	failures, err = applyAutoNames(withCheckContext(ctx, req, olds), &i)
+	if err != nil {
+		return p.CheckResponse{}, err
+	}
 
 	inputs, err := encoder.Encode(i)
+	if len(failures) > 0 {
+		return p.CheckResponse{Inputs: applySecrets[I](inputs), Failures: failures}, err
+	}
 
 	return p.CheckResponse{Inputs: applySecrets[I](inputs)}, err
 }
@@ -1056,14 +1321,15 @@ type (
 //
 // callCustomCheck facilitates extracting the encoder created with [DefaultCheck].
 func callCustomCheck[T any](
-	ctx context.Context, r CustomCheck[T], name string, olds, news property.Map,
+	ctx context.Context, r CustomCheck[T], name string, olds, news property.Map, randomSeed []byte,
 ) (*ende.Encoder, T, []p.CheckFailure, error) {
 	defaultCheckEncoder := new(defaultCheckEncoderValue)
 	ctx = context.WithValue(ctx, defaultCheckEncoderKey{}, defaultCheckEncoder)
 	resp, err := r.Check(ctx, CheckRequest{
-		Name:      name,
-		OldInputs: olds,
-		NewInputs: news,
+		Name:       name,
+		OldInputs:  olds,
+		NewInputs:  news,
+		RandomSeed: randomSeed,
 	})
 	return defaultCheckEncoder.enc, resp.Inputs, resp.Failures, err
 }
@@ -1071,7 +1337,8 @@ func callCustomCheck[T any](
 // DefaultCheck verifies that inputs can deserialize cleanly into I. This is the default
 // validation that is performed when leaving Check unimplemented.
 //
-// It also adds defaults to inputs as necessary, as defined by [Annotator.SetDefault].
+// It also adds defaults to inputs as necessary, as defined by [Annotator.SetDefault], and
+// generates names for fields annotated with [Annotator.SetAutoName].
 func DefaultCheck[I any](ctx context.Context, inputs property.Map) (I, []p.CheckFailure, error) {
 	enc, i, failures, err := decodeCheckingMapErrors[I](inputs)
 
@@ -1083,8 +1350,11 @@ func DefaultCheck[I any](ctx context.Context, inputs property.Map) (I, []p.Check
 		return i, failures, err
 	}
 
-	i, err = defaultCheck(i)
-	return i, nil, err
+	if i, err = defaultCheck(i); err != nil {
+		return i, nil, err
+	}
+	failures, err = applyAutoNames(ctx, &i)
+	return i, failures, err
 }
 
 func defaultCheck[I any](i I) (I, error) {
@@ -1154,6 +1424,11 @@ func (rc *derivedResourceController[R, I, O]) Diff(ctx context.Context, req p.Di
 func diff[R, I, O any](
 	ctx context.Context, req p.DiffRequest, r *R, forceReplace func(string) bool,
 ) (p.DiffResponse, error) {
+	// Properties set under an alias are the same field as properties set under its
+	// current name.
+	req.State = ende.ResolveAliases(req.State, reflect.TypeFor[O]())
+	req.Inputs = ende.ResolveAliases(req.Inputs, reflect.TypeFor[I]())
+
 	for _, ignoredChange := range req.IgnoreChanges {
 		v, ok := req.State.GetOk(ignoredChange)
 		if ok {
@@ -1187,9 +1462,13 @@ func diff[R, I, O any](
 	}
 	// Olds is an Output, but news is an Input. Output should be a superset of Input,
 	// so we need to filter out fields that are in Output but not Input.
+	oldState, err := migrateInputs[R, I](ctx, req.State)
+	if err != nil {
+		return p.DiffResponse{}, err
+	}
 	oldInputs := map[string]property.Value{}
 	for k := range inputProps {
-		oldInputs[k] = req.State.Get(k)
+		oldInputs[k] = oldState.Get(k)
 	}
 	objDiff := resource.ToResourcePropertyValue(property.New(oldInputs)).ObjectValue().Diff(
 		resource.ToResourcePropertyValue(property.New(req.Inputs)).ObjectValue(),
@@ -1235,6 +1514,17 @@ func (rc *derivedResourceController[R, I, O]) Create(
 	ctx context.Context, req p.CreateRequest,
 ) (resp p.CreateResponse, retError error) {
 	r := rc.getInstance()
+	create, ok := any(*r).(CustomCreate[I, O])
+	if !ok {
+		return p.CreateResponse{}, status.Errorf(codes.Unimplemented,
+			"%s is read-only: it cannot be created, only read with its get function", req.Urn.Type())
+	}
+
+	ctx, cancel := rc.withTimeout(ctx, req.Timeout, func(t introspect.Timeouts) time.Duration {
+		return t.Create
+	})
+	defer cancel()
+	deadline, _ := ctx.Deadline()
 
 	var err error
 	encoder, input, err := ende.Decode[I](req.Properties)
@@ -1242,11 +1532,31 @@ func (rc *derivedResourceController[R, I, O]) Create(
 		return p.CreateResponse{}, fmt.Errorf("invalid inputs: %w", err)
 	}
 
-	inferResp, err := (*r).Create(ctx, CreateRequest[I]{
-		Name:   req.Urn.Name(),
-		Inputs: input,
-		DryRun: req.DryRun,
-	})
+	var inferResp CreateResponse[O]
+	preview, previewed := any(*r).(CustomPreview[I, O])
+	previewed = previewed && req.DryRun
+	var previewResp *PreviewResponse[O]
+	if previewed {
+		previewResp = new(PreviewResponse[O])
+		*previewResp, err = preview.Preview(ctx, PreviewRequest[I, O]{
+			Name:     req.Urn.Name(),
+			Inputs:   input,
+			IsCreate: true,
+			unknowns: newUnknownFields(req.Properties),
+		})
+		inferResp = CreateResponse[O]{ID: previewResp.ID, Output: previewResp.Output}
+	} else {
+		err = rc.retry(ctx, OperationCreate, func() (err error) {
+			inferResp, err = create.Create(ctx, CreateRequest[I]{
+				Name:     req.Urn.Name(),
+				Inputs:   input,
+				DryRun:   req.DryRun,
+				Deadline: deadline,
+				unknowns: newUnknownFields(req.Properties),
+			})
+			return err
+		})
+	}
 	if initFailed := (ResourceInitFailedError{}); errors.As(err, &initFailed) {
 		defer func(createErr error) {
 			// If there was an error, it indicates a problem with serializing
@@ -1280,11 +1590,14 @@ func (rc *derivedResourceController[R, I, O]) Create(
 		return p.CreateResponse{}, fmt.Errorf("encoding resource properties: %w", err)
 	}
 
-	setDeps, err := getDependencies(r, &input, &inferResp.Output, true /* isCreate */, req.DryRun)
+	setDeps, err := getDependencies(r, &input, &inferResp.Output, true /* isCreate */, req.DryRun, previewResp)
 	if err != nil {
 		return p.CreateResponse{}, err
 	}
 	setDeps(nil, resource.ToResourcePropertyValue(property.New(req.Properties)).ObjectValue(), m)
+	if err := stampStateVersion[O](ctx, *r, m); err != nil {
+		return p.CreateResponse{}, err
+	}
 
 	return p.CreateResponse{
 		ID:         inferResp.ID,
@@ -1341,10 +1654,14 @@ func (rc *derivedResourceController[R, I, O]) Read(
 			Inputs:     req.Inputs,
 		}, nil
 	}
-	inferResp, err := read.Read(ctx, ReadRequest[I, O]{
-		ID:     req.ID,
-		Inputs: inputs,
-		State:  state,
+	var inferResp ReadResponse[I, O]
+	err = rc.retry(ctx, OperationRead, func() (err error) {
+		inferResp, err = read.Read(ctx, ReadRequest[I, O]{
+			ID:     req.ID,
+			Inputs: inputs,
+			State:  state,
+		})
+		return err
 	})
 	if initFailed := (ResourceInitFailedError{}); errors.As(err, &initFailed) {
 		defer func(readErr error) {
@@ -1378,6 +1695,9 @@ func (rc *derivedResourceController[R, I, O]) Read(
 	if err != nil {
 		return p.ReadResponse{}, err
 	}
+	if err := stampStateVersion[O](ctx, *r, s); err != nil {
+		return p.ReadResponse{}, err
+	}
 
 	return p.ReadResponse{
 		ID:         inferResp.ID,
@@ -1402,6 +1722,12 @@ func (rc *derivedResourceController[R, I, O]) Update(
 		}
 	}
 
+	ctx, cancel := rc.withTimeout(ctx, req.Timeout, func(t introspect.Timeouts) time.Duration {
+		return t.Update
+	})
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+
 	_, olds, err := hydrateFromState[R, I, O](ctx, req.State)
 	if err != nil {
 		return p.UpdateResponse{}, err
@@ -1410,12 +1736,33 @@ func (rc *derivedResourceController[R, I, O]) Update(
 	if err != nil {
 		return p.UpdateResponse{}, err
 	}
-	inferResp, err := update.Update(ctx, UpdateRequest[I, O]{
-		ID:     req.ID,
-		State:  olds,
-		Inputs: news,
-		DryRun: req.DryRun,
-	})
+	var inferResp UpdateResponse[O]
+	preview, previewed := any(*r).(CustomPreview[I, O])
+	previewed = previewed && req.DryRun
+	var previewResp *PreviewResponse[O]
+	if previewed {
+		previewResp = new(PreviewResponse[O])
+		*previewResp, err = preview.Preview(ctx, PreviewRequest[I, O]{
+			Name:     req.Urn.Name(),
+			ID:       req.ID,
+			Inputs:   news,
+			State:    olds,
+			unknowns: newUnknownFields(req.Inputs),
+		})
+		inferResp = UpdateResponse[O]{Output: previewResp.Output}
+	} else {
+		err = rc.retry(ctx, OperationUpdate, func() (err error) {
+			inferResp, err = update.Update(ctx, UpdateRequest[I, O]{
+				ID:       req.ID,
+				State:    olds,
+				Inputs:   news,
+				DryRun:   req.DryRun,
+				Deadline: deadline,
+				unknowns: newUnknownFields(req.Inputs),
+			})
+			return err
+		})
+	}
 	if initFailed := (ResourceInitFailedError{}); errors.As(err, &initFailed) {
 		defer func(updateErr error) {
 			// If there was an error, it indicates a problem with serializing
@@ -1445,7 +1792,7 @@ func (rc *derivedResourceController[R, I, O]) Update(
 	if err != nil {
 		return p.UpdateResponse{}, err
 	}
-	setDeps, err := getDependencies(r, &news, &inferResp.Output, false /* isCreate */, req.DryRun)
+	setDeps, err := getDependencies(r, &news, &inferResp.Output, false /* isCreate */, req.DryRun, previewResp)
 	if err != nil {
 		return p.UpdateResponse{}, err
 	}
@@ -1454,6 +1801,9 @@ func (rc *derivedResourceController[R, I, O]) Update(
 		resource.ToResourcePropertyValue(property.New(req.Inputs)).ObjectValue(),
 		m,
 	)
+	if err := stampStateVersion[O](ctx, *r, m); err != nil {
+		return p.UpdateResponse{}, err
+	}
 
 	return p.UpdateResponse{
 		Properties: resource.FromResourcePropertyValue(resource.NewProperty(m)).AsMap(),
@@ -1464,32 +1814,162 @@ func (rc *derivedResourceController[R, I, O]) Delete(ctx context.Context, req p.
 	r := rc.getInstance()
 	del, ok := ((interface{})(*r)).(CustomDelete[O])
 	if ok {
+		ctx, cancel := rc.withTimeout(ctx, req.Timeout, func(t introspect.Timeouts) time.Duration {
+			return t.Delete
+		})
+		defer cancel()
+		deadline, _ := ctx.Deadline()
+
 		_, olds, err := hydrateFromState[R, I, O](ctx, req.Properties)
 		if err != nil {
 			return err
 		}
-		_, err = del.Delete(ctx, DeleteRequest[O]{
-			ID:    req.ID,
-			State: olds,
+		return rc.retry(ctx, OperationDelete, func() error {
+			_, err := del.Delete(ctx, DeleteRequest[O]{
+				ID:       req.ID,
+				State:    olds,
+				Deadline: deadline,
+			})
+			return err
 		})
-		return err
 	}
 	return nil
 }
 
+// withTimeout bounds ctx by the timeout sent by the engine, in seconds. If the engine did
+// not send a timeout, the default timeout declared with [Annotator.SetDefaultTimeouts] is
+// used instead.
+func (rc *derivedResourceController[R, I, O]) withTimeout(
+	ctx context.Context, timeout float64, getDefault func(introspect.Timeouts) time.Duration,
+) (context.Context, context.CancelFunc) {
+	d := time.Duration(timeout * float64(time.Second))
+	if d <= 0 {
+		if r, ok := any(*rc.receiver).(Annotated); ok {
+			a := introspect.NewAnnotator(r)
+			r.Annotate(&a)
+			d = getDefault(a.DefaultTimeouts)
+		}
+	}
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// retry calls f under the [RetryPolicy] declared by the resource or the provider config.
+func (rc *derivedResourceController[R, I, O]) retry(ctx context.Context, op Operation, f func() error) error {
+	policy, ok := retryPolicy(ctx, *rc.receiver)
+	if !ok {
+		return f()
+	}
+	return policy.retry(ctx, op, f)
+}
+
+// unknownFields holds the inputs of a request, so that fields of the decoded inputs can
+// be checked for unknown values.
+type unknownFields struct {
+	inputs property.Map
+}
+
+func newUnknownFields(inputs property.Map) unknownFields {
+	return unknownFields{inputs: inputs}
+}
+
+// has reports if field, a pointer into inputs, refers to an unknown value.
+//
+// field may point to inputs itself, or to any value reachable from inputs through struct
+// fields, pointers, slices and arrays. has reports false for any other pointer.
+func (u unknownFields) has(inputs, field any) bool {
+	unknown, _ := findUnknown(reflect.ValueOf(inputs).Elem(), property.New(u.inputs), field)
+	return unknown
+}
+
+// findUnknown searches v for the value that field points to, returning whether the
+// matching part of prop holds an unknown value and whether field was found.
+func findUnknown(v reflect.Value, prop property.Value, field any) (unknown, found bool) {
+	if v.CanAddr() && v.Addr().Interface() == field {
+		return prop.HasComputed(), true
+	}
+	// Every value nested within an unknown value is unknown.
+	elem := func(get func() property.Value) property.Value {
+		if prop.IsComputed() {
+			return prop
+		}
+		return get()
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return findUnknown(v.Elem(), prop, field)
+		}
+	case reflect.Struct:
+		for _, f := range reflect.VisibleFields(v.Type()) {
+			if !f.IsExported() || f.Anonymous {
+				continue
+			}
+			fv, err := v.FieldByIndexErr(f.Index)
+			if err != nil {
+				// The field is promoted through a nil embedded pointer.
+				continue
+			}
+			tag, err := introspect.ParseTag(f)
+			if err != nil || tag.Internal {
+				continue
+			}
+			fieldProp := elem(func() property.Value {
+				if !prop.IsMap() {
+					return property.Value{}
+				}
+				m := prop.AsMap()
+				for _, name := range append([]string{tag.Name}, tag.Aliases...) {
+					if p, ok := m.GetOk(name); ok {
+						return p
+					}
+				}
+				return property.Value{}
+			})
+			if unknown, found := findUnknown(fv, fieldProp, field); found {
+				return unknown, true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			elemProp := elem(func() property.Value {
+				if !prop.IsArray() || i >= prop.AsArray().Len() {
+					return property.Value{}
+				}
+				return prop.AsArray().Get(i)
+			})
+			if unknown, found := findUnknown(v.Index(i), elemProp, field); found {
+				return unknown, true
+			}
+		}
+	}
+	return false, false
+}
+
 // Apply dependencies to a property map, flowing secretness and computedness from input to
 // output.
 type setDeps func(oldInputs, input, output resource.PropertyMap)
 
 // Get the decency mapping between inputs and outputs of a resource.
+//
+// If output was computed by [CustomPreview], preview is its response: the fields it set
+// are known and the others are unknown.
 func getDependencies[R, I, O any](
-	r *R, input *I, output *O, isCreate, isPreview bool,
+	r *R, input *I, output *O, isCreate, isPreview bool, preview *PreviewResponse[O],
 ) (setDeps, error) {
 	var wire func(FieldSelector)
 
-	if r, ok := ((interface{})(*r)).(ExplicitDependencies[I, O]); ok {
+	explicit, hasExplicit := ((interface{})(*r)).(ExplicitDependencies[I, O])
+	if hasExplicit || preview != nil {
 		wire = func(fg FieldSelector) {
-			r.WireDependencies(fg, input, output)
+			if hasExplicit {
+				explicit.WireDependencies(fg, input, output)
+			}
+			if preview != nil {
+				fg.(*fieldGenerator).markPreviewed(output, preview.known)
+			}
 		}
 	}
 	return getDependenciesRaw(input, output, wire, isCreate, isPreview)
@@ -1524,8 +2004,18 @@ func hydrateFromState[R, I, O any](
 	ctx context.Context, state property.Map,
 ) (ende.Encoder, O, error) {
 	var r R
+	if migrations, ok, err := stateVersions[O](ctx, r); ok {
+		if err == nil {
+			state, err = upgradeState(ctx, migrations, state)
+		}
+		if err != nil {
+			var o O
+			return ende.Encoder{}, o, err
+		}
+		return ende.Decode[O](state)
+	}
 	if r, ok := ((interface{})(r)).(CustomStateMigrations[O]); ok {
-		enc, newState, didMigrate, err := migrateState(ctx, r, state)
+		enc, newState, didMigrate, err := migrate(ctx, r.StateMigrations(ctx), state, ende.DecodeAny)
 		if err != nil || didMigrate {
 			return enc, newState, err
 		}
@@ -1534,11 +2024,57 @@ func hydrateFromState[R, I, O any](
 	return ende.Decode[O](state)
 }
 
-func migrateState[O any](
-	ctx context.Context, r CustomStateMigrations[O], state property.Map,
-) (ende.Encoder, O, bool, error) {
-	var o O
-	for _, upgrader := range r.StateMigrations(ctx) {
+// migrateInputs upgrades old inputs with the migrations declared by
+// [CustomInputMigrations]. Properties of inputs that are not part of an old input shape
+// are ignored, so inputs may also be the state of the resource.
+//
+// If no migration applies, inputs are returned unchanged.
+func migrateInputs[R, I any](ctx context.Context, inputs property.Map) (property.Map, error) {
+	var r R
+	m, ok := ((interface{})(r)).(CustomInputMigrations[I])
+	if !ok {
+		return inputs, nil
+	}
+	_, newInputs, didMigrate, err := migrate(ctx, m.InputMigrations(ctx), inputs,
+		ende.DecodeTolerateUnrecognized)
+	if err != nil || !didMigrate {
+		return inputs, err
+	}
+
+	// The encoder that decoded the old shape describes paths in the old shape, so
+	// it cannot be applied to the new shape.
+	migrated, err := ende.Encoder{}.Encode(newInputs)
+	if err != nil {
+		return inputs, fmt.Errorf("encoding migrated inputs: %w", err)
+	}
+	return keepSecrets(ende.ResolveAliases(inputs, reflect.TypeFor[I]()),
+		resource.FromResourcePropertyValue(resource.NewProperty(migrated)).AsMap()), nil
+}
+
+// keepSecrets marks the inputs of migrated secret when the old input they came from was
+// secret. An input is matched to the old input of the same name, or to the old input set
+// under one of its aliases, so olds must have its aliases resolved.
+//
+// Without this, a migrated secret would differ from the same secret sent by the engine.
+func keepSecrets(olds, migrated property.Map) property.Map {
+	m := make(map[string]property.Value, migrated.Len())
+	for k, v := range migrated.All {
+		if old, ok := olds.GetOk(k); ok && old.Secret() {
+			v = v.WithSecret(true)
+		}
+		m[k] = v
+	}
+	return property.NewMap(m)
+}
+
+// migrate runs the first applicable migration on state. decodeOld is used to decode m into
+// the old shape of each migration.
+func migrate[T any](
+	ctx context.Context, migrations []StateMigrationFunc[T], state property.Map,
+	decodeOld func(property.Map, any) (ende.Encoder, mapper.MappingError),
+) (ende.Encoder, T, bool, error) {
+	var o T
+	for _, upgrader := range migrations {
 		oldType := upgrader.oldShape()
 		f := upgrader.migrateFunc()
 
@@ -1555,7 +2091,7 @@ func migrateState[O any](
 			oldValue := reflect.New(oldType)
 
 			var err error
-			enc, err = ende.DecodeAny(state, oldValue.Interface())
+			enc, err = decodeOld(state, oldValue.Interface())
 			if err != nil {
 				// If we couldn't encode cleanly, then state doesn't fit into the migrator.
 				continue
@@ -1576,7 +2112,7 @@ func migrateState[O any](
 		if err != nil {
 			return ende.Encoder{}, o, true, err
 		}
-		result, ok := results[0].Interface().(MigrationResult[O])
+		result, ok := results[0].Interface().(MigrationResult[T])
 		contract.Assertf(ok,
 			"The signature guarantees of f mandate the second argument is an %T, found %T",
 			result, results[0].Interface())
@@ -0,0 +1,215 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/internal/introspect"
+	mContext "github.com/pulumi/pulumi-go-provider/middleware/context"
+)
+
+// The struct tags below must match the signature in the ende package, which recognizes
+// resource references by their shape.
+
+// ResourceRef is a reference to a custom resource of type R, where R is a resource
+// controller such as the one passed to [Resource].
+//
+// ResourceRef[R] is described in the schema as a reference to R, so that programs pass the
+// resource itself instead of its ID:
+//
+//	type SubnetArgs struct {
+//		Vpc  infer.ResourceRef[*Vpc] `pulumi:"vpc"`
+//		Cidr string                  `pulumi:"cidr"`
+//	}
+//
+// ID is empty when the referenced resource has not yet been created, which can only
+// happen during a preview. The state of the referenced resource is available from
+// [RefState] when R is managed by the same provider.
+type ResourceRef[R any] struct {
+	// The URN of the referenced resource.
+	URN resource.URN `pulumi:"5e0b8d2a71c94f36a8e1d7b04c6f29350,optional"`
+	// The ID of the referenced resource, or "" if it is not yet known.
+	ID resource.ID `pulumi:"5e0b8d2a71c94f36a8e1d7b04c6f29351,optional"`
+	// The version of the package that provides the referenced resource.
+	PackageVersion string `pulumi:"5e0b8d2a71c94f36a8e1d7b04c6f29352,optional"`
+}
+
+func (ResourceRef[R]) resourceToken(namer TokenNamer) (tokens.Type, error) {
+	r := new(R)
+	// Annotate is called on the resource, so a pointer must point to a value.
+	if t := reflect.TypeFor[R](); t.Kind() == reflect.Pointer {
+		reflect.ValueOf(r).Elem().Set(reflect.New(t.Elem()))
+	}
+	return (&derivedResourceController[R, struct{}, struct{}]{receiver: r, namer: namer}).GetToken()
+}
+
+// resourceRef is implemented by [ResourceRef].
+type resourceRef interface {
+	resourceToken(namer TokenNamer) (tokens.Type, error)
+}
+
+// hasResourceRef reports if T holds a [ResourceRef].
+func hasResourceRef[T any]() bool {
+	refType := reflect.TypeFor[resourceRef]()
+	found := false
+	seen := map[reflect.Type]bool{}
+	_ = crawlTypes[T](func(t reflect.Type, _ bool, _ *introspect.FieldTag, _, _ string) (bool, error) {
+		if t.Implements(refType) {
+			found = true
+		}
+		drill := !found && !seen[t]
+		seen[t] = true
+		return drill, nil
+	})
+	return found
+}
+
+// RefState returns the state of the resource that ref refers to, where O is the output type
+// of R.
+//
+// The state is known if this provider has created, read or updated the referenced resource
+// while it has been running. It is not known during a preview, when the referenced resource
+// is unchanged by the update, or if R is managed by another provider.
+//
+//	vpc, ok := infer.RefState[VpcState](ctx, req.Inputs.Vpc)
+//
+// Note: RefState will panic if O is not the output type of R.
+func RefState[O, R any](ctx context.Context, ref ResourceRef[R]) (O, bool) {
+	var o O
+	if want, ok := resourceOutputType(reflect.TypeFor[R]()); ok && want != reflect.TypeFor[O]() {
+		panic(fmt.Sprintf("RefState[%s] called on a reference to a resource with outputs of type %s",
+			reflect.TypeFor[O](), want))
+	}
+	states, ok := ctx.Value(resourceStatesKey{}).(*resourceStates)
+	if !ok || ref.URN == "" {
+		return o, false
+	}
+	state, ok := states.get(ref.URN)
+	if !ok {
+		return o, false
+	}
+	_, o, err := hydrateFromState[R, struct{}, O](ctx, state)
+	return o, err == nil
+}
+
+// resourceOutputType returns the type of the outputs of the resource controller t, found
+// from its Create or Read method.
+func resourceOutputType(t reflect.Type) (reflect.Type, bool) {
+	for _, name := range []string{"Create", "Read"} {
+		m, ok := t.MethodByName(name)
+		if !ok {
+			m, ok = reflect.PointerTo(t).MethodByName(name)
+		}
+		if !ok || m.Type.NumOut() != 2 || m.Type.Out(0).Kind() != reflect.Struct {
+			continue
+		}
+		for _, field := range []string{"Output", "State"} {
+			if f, ok := m.Type.Out(0).FieldByName(field); ok {
+				return f.Type, true
+			}
+		}
+	}
+	return nil, false
+}
+
+type resourceStatesKey struct{}
+
+// resourceStates holds the last known state of each resource managed by a provider, for
+// [RefState].
+type resourceStates struct {
+	mu     sync.Mutex
+	states map[resource.URN]property.Map
+}
+
+func (s *resourceStates) get(urn resource.URN) (property.Map, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[urn]
+	return state, ok
+}
+
+func (s *resourceStates) set(urn resource.URN, state property.Map) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[urn] = state
+}
+
+func (s *resourceStates) delete(urn resource.URN) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, urn)
+}
+
+// recordResourceStates wraps provider so that the state of each resource it manages is
+// recorded, and is available to [RefState].
+//
+// The states of resources that are only previewed are not recorded, since they may hold
+// unknown values.
+func recordResourceStates(provider p.Provider) p.Provider {
+	states := &resourceStates{states: map[resource.URN]property.Map{}}
+	if create := provider.Create; create != nil {
+		provider.Create = func(ctx context.Context, req p.CreateRequest) (p.CreateResponse, error) {
+			resp, err := create(ctx, req)
+			if err == nil && !req.DryRun {
+				states.set(req.Urn, resp.Properties)
+			}
+			return resp, err
+		}
+	}
+	if read := provider.Read; read != nil {
+		provider.Read = func(ctx context.Context, req p.ReadRequest) (p.ReadResponse, error) {
+			resp, err := read(ctx, req)
+			switch {
+			case err != nil:
+			case resp.ID == "":
+				// The resource no longer exists.
+				states.delete(req.Urn)
+			default:
+				states.set(req.Urn, resp.Properties)
+			}
+			return resp, err
+		}
+	}
+	if update := provider.Update; update != nil {
+		provider.Update = func(ctx context.Context, req p.UpdateRequest) (p.UpdateResponse, error) {
+			resp, err := update(ctx, req)
+			if err == nil && !req.DryRun {
+				states.set(req.Urn, resp.Properties)
+			}
+			return resp, err
+		}
+	}
+	if del := provider.Delete; del != nil {
+		provider.Delete = func(ctx context.Context, req p.DeleteRequest) error {
+			err := del(ctx, req)
+			if err == nil {
+				states.delete(req.Urn)
+			}
+			return err
+		}
+	}
+	return mContext.Wrap(provider, func(ctx context.Context) context.Context {
+		return context.WithValue(ctx, resourceStatesKey{}, states)
+	})
+}
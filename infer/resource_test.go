@@ -538,3 +538,86 @@ func TestCheck(t *testing.T) {
 		})
 	}
 }
+
+type migrateSecretsResource struct{}
+
+type migrateSecretsArgsV1 struct {
+	Size     int `pulumi:"size"`
+	Password int `pulumi:"password"`
+	Enabled  int `pulumi:"enabled"`
+}
+
+type migrateSecretsArgs struct {
+	SizeGb  int `pulumi:"sizeGb"`
+	Pin     int `pulumi:"pin" provider:"alias=password"`
+	Enabled int `pulumi:"enabled"`
+}
+
+func (migrateSecretsResource) InputMigrations(context.Context) []StateMigrationFunc[migrateSecretsArgs] {
+	return []StateMigrationFunc[migrateSecretsArgs]{
+		StateMigration(func(_ context.Context, v1 migrateSecretsArgsV1) (MigrationResult[migrateSecretsArgs], error) {
+			return MigrationResult[migrateSecretsArgs]{Result: &migrateSecretsArgs{
+				SizeGb:  v1.Size,
+				Pin:     v1.Password,
+				Enabled: v1.Enabled,
+			}}, nil
+		}),
+	}
+}
+
+func TestMigrateInputsSecrets(t *testing.T) {
+	t.Parallel()
+
+	type m = map[string]property.Value
+
+	migrated, err := migrateInputs[migrateSecretsResource, migrateSecretsArgs](t.Context(), property.NewMap(m{
+		"size":     property.New(1.0).WithSecret(true),
+		"password": property.New(1.0).WithSecret(true),
+		"enabled":  property.New(1.0),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, property.NewMap(m{
+		// Renamed by the migration, without a declared alias.
+		"sizeGb": property.New(1.0),
+		// Renamed with a declared alias.
+		"pin": property.New(1.0).WithSecret(true),
+		// Not secret, even though it has the same value as secret old inputs.
+		"enabled": property.New(1.0),
+	}), migrated)
+
+	migrated, err = migrateInputs[migrateSecretsResource, migrateSecretsArgs](t.Context(), property.NewMap(m{
+		"size":     property.New(1.0),
+		"password": property.New(2.0),
+		"enabled":  property.New(3.0).WithSecret(true),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, property.NewMap(m{
+		"sizeGb":  property.New(1.0),
+		"pin":     property.New(2.0),
+		"enabled": property.New(3.0).WithSecret(true),
+	}), migrated)
+}
+
+func TestHasResourceRef(t *testing.T) {
+	t.Parallel()
+
+	type node struct {
+		Children []node `pulumi:"children"`
+	}
+	type nested struct {
+		Refs map[string]*ResourceRef[*checkResource] `pulumi:"refs"`
+	}
+
+	assert.False(t, hasResourceRef[struct {
+		Name string `pulumi:"name"`
+	}]())
+	assert.False(t, hasResourceRef[struct {
+		Root node `pulumi:"root"`
+	}]())
+	assert.True(t, hasResourceRef[struct {
+		Ref ResourceRef[*checkResource] `pulumi:"ref"`
+	}]())
+	assert.True(t, hasResourceRef[struct {
+		Nested []nested `pulumi:"nested"`
+	}]())
+}
@@ -0,0 +1,123 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	p "github.com/pulumi/pulumi-go-provider"
+)
+
+// Operation identifies a resource operation that a [RetryPolicy] may retry.
+type Operation string
+
+const (
+	OperationCreate Operation = "create"
+	OperationRead   Operation = "read"
+	OperationUpdate Operation = "update"
+	OperationDelete Operation = "delete"
+)
+
+// RetryPolicy describes how failed resource operations are retried.
+//
+// Only operations listed in Idempotent are retried, since retrying an operation that
+// partially succeeded may not be safe. Each retry is logged as a warning.
+type RetryPolicy struct {
+	// Idempotent lists the operations that are safe to retry.
+	Idempotent []Operation
+	// MaxAttempts is the maximum number of attempts for each operation, including the
+	// first. It defaults to 3.
+	MaxAttempts int
+	// MinDelay is the delay before the first retry. It defaults to one second.
+	MinDelay time.Duration
+	// MaxDelay caps the delay between retries. It defaults to 30 seconds.
+	MaxDelay time.Duration
+	// Retryable reports if an error is transient. Only transient errors are retried, so
+	// if Retryable is nil, no errors are retried.
+	//
+	// [RetryOnErrorType] builds a Retryable function that matches on error types.
+	Retryable func(error) bool
+}
+
+// CustomRetryPolicy describes a resource or a provider config that declares a [RetryPolicy].
+//
+// A policy declared by a resource takes precedence over a policy declared by the provider
+// config.
+type CustomRetryPolicy interface {
+	RetryPolicy() RetryPolicy
+}
+
+// RetryOnErrorType returns a function for [RetryPolicy.Retryable] that reports errors
+// which wrap an error of type E as transient.
+func RetryOnErrorType[E error]() func(error) bool {
+	return func(err error) bool {
+		var target E
+		return errors.As(err, &target)
+	}
+}
+
+// retryPolicy returns the retry policy that applies to r, if any.
+func retryPolicy(ctx context.Context, r any) (RetryPolicy, bool) {
+	if r, ok := r.(CustomRetryPolicy); ok {
+		return r.RetryPolicy(), true
+	}
+	if c, ok := ctx.Value(configKey).(InferredConfig); ok {
+		return c.retryPolicy()
+	}
+	return RetryPolicy{}, false
+}
+
+// retry calls f until it succeeds, its error is not retryable, or the policy runs out of
+// attempts. An error is retryable only if policy.Retryable reports it as transient.
+//
+// Errors that signal a partially successful operation, such as [ResourceInitFailedError],
+// are never retried.
+func (policy RetryPolicy) retry(ctx context.Context, op Operation, f func() error) error {
+	if !slices.Contains(policy.Idempotent, op) {
+		return f()
+	}
+
+	maxAttempts, minDelay, maxDelay := policy.MaxAttempts, policy.MinDelay, policy.MaxDelay
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	if minDelay <= 0 {
+		minDelay = time.Second
+	}
+	if maxDelay <= 0 {
+		maxDelay = 30 * time.Second
+	}
+	maxDelay = max(minDelay, maxDelay)
+
+	delay := minDelay
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= maxAttempts || ctx.Err() != nil ||
+			errors.As(err, &ResourceInitFailedError{}) ||
+			policy.Retryable == nil || !policy.Retryable(err) {
+			return err
+		}
+
+		p.GetLogger(ctx).Warningf("%s failed (attempt %d of %d), retrying: %s",
+			op, attempt, maxAttempts, err)
+		if !sleepWithJitter(ctx, delay) {
+			return err
+		}
+		delay = min(2*delay, maxDelay)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type throttledError struct{}
+
+func (throttledError) Error() string { return "429: too many requests" }
+
+func TestRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	// failing returns a function that fails with err the first n times it is called.
+	failing := func(n int, err error) (func() error, *int) {
+		var calls int
+		return func() error {
+			calls++
+			if calls <= n {
+				return err
+			}
+			return nil
+		}, &calls
+	}
+
+	policy := RetryPolicy{
+		Idempotent: []Operation{OperationCreate},
+		MinDelay:   time.Millisecond,
+		Retryable:  RetryOnErrorType[throttledError](),
+	}
+
+	t.Run("retryable", func(t *testing.T) {
+		t.Parallel()
+
+		f, calls := failing(2, throttledError{})
+		assert.NoError(t, policy.retry(t.Context(), OperationCreate, f))
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("max-attempts", func(t *testing.T) {
+		t.Parallel()
+
+		f, calls := failing(5, throttledError{})
+		assert.ErrorIs(t, policy.retry(t.Context(), OperationCreate, f), throttledError{})
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("not-retryable", func(t *testing.T) {
+		t.Parallel()
+
+		f, calls := failing(1, errors.New("bad request"))
+		assert.ErrorContains(t, policy.retry(t.Context(), OperationCreate, f), "bad request")
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("not-idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		f, calls := failing(1, throttledError{})
+		assert.ErrorIs(t, policy.retry(t.Context(), OperationUpdate, f), throttledError{})
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("no-classifier", func(t *testing.T) {
+		t.Parallel()
+
+		policy := RetryPolicy{Idempotent: []Operation{OperationCreate}, MinDelay: time.Millisecond}
+		f, calls := failing(1, throttledError{})
+		assert.ErrorIs(t, policy.retry(t.Context(), OperationCreate, f), throttledError{})
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("partial-success", func(t *testing.T) {
+		t.Parallel()
+
+		policy := RetryPolicy{
+			Idempotent: []Operation{OperationCreate},
+			MinDelay:   time.Millisecond,
+			Retryable:  func(error) bool { return true },
+		}
+		f, calls := failing(1, ResourceInitFailedError{Reasons: []string{"unhealthy"}})
+		assert.ErrorAs(t, policy.retry(t.Context(), OperationCreate, f), &ResourceInitFailedError{})
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		f, calls := failing(1, throttledError{})
+		assert.ErrorIs(t, policy.retry(ctx, OperationCreate, f), throttledError{})
+		assert.Equal(t, 1, *calls)
+	})
+}
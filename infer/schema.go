@@ -15,8 +15,11 @@
 package infer
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
@@ -24,6 +27,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 
+	"github.com/pulumi/pulumi-go-provider/infer/internal/ende"
 	"github.com/pulumi/pulumi-go-provider/infer/types"
 	"github.com/pulumi/pulumi-go-provider/internal/introspect"
 	sch "github.com/pulumi/pulumi-go-provider/middleware/schema"
@@ -64,7 +68,23 @@ func getAnnotated(t reflect.Type) introspect.Annotator {
 			(*dst).DeprecationMessages[k] = v
 		}
 		dst.Token = src.Token
+		if src.GenericFormat != "" {
+			dst.GenericFormat = src.GenericFormat
+		}
 		dst.Aliases = append(dst.Aliases, src.Aliases...)
+		if src.DefaultTimeouts != (introspect.Timeouts{}) {
+			dst.DefaultTimeouts = src.DefaultTimeouts
+		}
+		for k, v := range src.AutoNames {
+			(*dst).AutoNames[k] = v
+		}
+		for k, v := range src.Languages {
+			if dst.Languages[k] == nil {
+				dst.Languages[k] = map[string]json.RawMessage{}
+			}
+			maps.Copy(dst.Languages[k], v)
+		}
+		dst.Examples = append(dst.Examples, src.Examples...)
 	}
 
 	ret := introspect.Annotator{
@@ -72,6 +92,8 @@ func getAnnotated(t reflect.Type) introspect.Annotator {
 		Defaults:            map[string]any{},
 		DefaultEnvs:         map[string][]string{},
 		DeprecationMessages: map[string]string{},
+		AutoNames:           map[string]introspect.AutoName{},
+		Languages:           map[string]map[string]json.RawMessage{},
 	}
 	if t.Elem().Kind() == reflect.Struct {
 		for _, f := range reflect.VisibleFields(t.Elem()) {
@@ -82,6 +104,8 @@ func getAnnotated(t reflect.Type) introspect.Annotator {
 		}
 	}
 
+	maps.Copy(ret.Descriptions, getRegisteredDescriptions(t.Elem()))
+
 	if r, ok := i.Interface().(Annotated); ok {
 		a := introspect.NewAnnotator(r)
 		r.Annotate(&a)
@@ -91,18 +115,34 @@ func getAnnotated(t reflect.Type) introspect.Annotator {
 	return ret
 }
 
-func getResourceSchema[R, I, O any](isComponent bool) (schema.ResourceSpec, multierror.Error) {
+// languageSpec returns the language specific information of the field name, or of the
+// annotated type itself if name is "".
+func languageSpec(annotations introspect.Annotator, name string) map[string]schema.RawMessage {
+	languages := annotations.Languages[name]
+	if len(languages) == 0 {
+		return nil
+	}
+	spec := make(map[string]schema.RawMessage, len(languages))
+	for lang, info := range languages {
+		spec[lang] = schema.RawMessage(info)
+	}
+	return spec
+}
+
+func getResourceSchema[R, I, O any](
+	isComponent bool, namer TokenNamer,
+) (schema.ResourceSpec, multierror.Error) {
 	var r R
 	var errs multierror.Error
 	annotations := getAnnotated(reflect.TypeOf(r))
 
-	properties, required, err := propertyListFromType(reflect.TypeOf(new(O)), isComponent, outputType)
+	properties, required, err := propertyListFromType(reflect.TypeOf(new(O)), isComponent, outputType, namer)
 	if err != nil {
 		var o O
 		errs.Errors = append(errs.Errors, fmt.Errorf("could not serialize output type %T: %w", o, err))
 	}
 
-	inputProperties, requiredInputs, err := propertyListFromType(reflect.TypeOf(new(I)), isComponent, inputType)
+	inputProperties, requiredInputs, err := propertyListFromType(reflect.TypeOf(new(I)), isComponent, inputType, namer)
 	if err != nil {
 		var i I
 		errs.Errors = append(errs.Errors, fmt.Errorf("could not serialize input type %T: %w", i, err))
@@ -117,8 +157,9 @@ func getResourceSchema[R, I, O any](isComponent bool) (schema.ResourceSpec, mult
 	return schema.ResourceSpec{
 		ObjectTypeSpec: schema.ObjectTypeSpec{
 			Properties:  properties,
-			Description: annotations.Descriptions[""],
+			Description: withExamples(annotations.Descriptions[""], annotations.Examples),
 			Required:    required,
+			Language:    languageSpec(annotations, ""),
 		},
 		InputProperties:    inputProperties,
 		RequiredInputs:     requiredInputs,
@@ -129,11 +170,18 @@ func getResourceSchema[R, I, O any](isComponent bool) (schema.ResourceSpec, mult
 }
 
 func serializeTypeAsPropertyType(
-	t reflect.Type, indicatePlain bool, extType *introspect.ExplicitType, propType propertyType,
+	t reflect.Type, indicatePlain bool, extType *introspect.ExplicitType, propType propertyType, namer TokenNamer,
 ) (schema.TypeSpec, error) {
 	for t.Kind() == reflect.Pointer {
 		t = t.Elem()
 	}
+	// Value wrappers such as Secret[T] are described by the type they hold.
+	if inner, ok := ende.Unwrap(t); ok {
+		return serializeTypeAsPropertyType(inner, indicatePlain, extType, propType, namer)
+	}
+	if variants, ok := ende.UnionVariants(t); ok {
+		return serializeUnion(variants, indicatePlain, propType, namer)
+	}
 	// Provider authors should not be using resource.Asset directly, but rather types.AssetOrArchive.
 	// We will returrn an error if resource.Asset is used directly for an input.
 	// pulumi/pulumi-go-provider#243
@@ -158,7 +206,9 @@ func serializeTypeAsPropertyType(
 			Ref: "pulumi.json#/Asset",
 		}, nil
 	}
-	if enum, ok := isEnum(t); ok {
+	if enum, ok, err := isEnum(t, namer); err != nil {
+		return schema.TypeSpec{}, err
+	} else if ok {
 		return schema.TypeSpec{
 			Ref: "#/types/" + enum.token,
 		}, nil
@@ -167,13 +217,21 @@ func serializeTypeAsPropertyType(
 	if err != nil {
 		return schema.TypeSpec{}, err
 	}
-	if tk, ok, err := resourceReferenceToken(t, extType, false); ok {
+	// JSON documents are written as the values they hold.
+	if ende.IsJSON(t) {
+		return schema.TypeSpec{Ref: "pulumi.json#/Json"}, nil
+	}
+	// Durations, times and other text types are written as strings, whatever their kind.
+	if ende.IsText(t) {
+		return schema.TypeSpec{Type: "string", Plain: !inputy && indicatePlain}, nil
+	}
+	if tk, ok, err := resourceReferenceToken(t, extType, false, namer); ok {
 		if err != nil {
 			return schema.TypeSpec{}, err
 		}
 		return tk, nil
 	}
-	if tk, ok, err := structReferenceToken(t, extType); ok {
+	if tk, ok, err := structReferenceToken(t, extType, namer); ok {
 		if err != nil {
 			return schema.TypeSpec{}, err
 		}
@@ -187,10 +245,13 @@ func serializeTypeAsPropertyType(
 
 	switch t.Kind() {
 	case reflect.Map:
-		if t.Key().Kind() != reflect.String {
-			return schema.TypeSpec{}, fmt.Errorf("map keys must be strings, found %s", t.Key().String())
+		// Keys are written as strings, so only types with a string form can be keys.
+		if !ende.IsMapKey(t.Key()) {
+			return schema.TypeSpec{}, fmt.Errorf(
+				"map keys must be strings, integers or implement encoding.TextMarshaler, found %s",
+				t.Key().String())
 		}
-		el, err := serializeTypeAsPropertyType(t.Elem(), indicatePlain, extType, propType)
+		el, err := serializeTypeAsPropertyType(t.Elem(), indicatePlain, extType, propType, namer)
 		if err != nil {
 			return schema.TypeSpec{}, err
 		}
@@ -199,7 +260,7 @@ func serializeTypeAsPropertyType(
 			AdditionalProperties: &el,
 		}, nil
 	case reflect.Array, reflect.Slice:
-		el, err := serializeTypeAsPropertyType(t.Elem(), indicatePlain, extType, propType)
+		el, err := serializeTypeAsPropertyType(t.Elem(), indicatePlain, extType, propType, namer)
 		if err != nil {
 			return schema.TypeSpec{}, err
 		}
@@ -209,9 +270,10 @@ func serializeTypeAsPropertyType(
 		}, nil
 	case reflect.Bool:
 		return primitive("boolean")
-	case reflect.Int, reflect.Int64, reflect.Int32:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		return primitive("integer")
-	case reflect.Float64:
+	case reflect.Float32, reflect.Float64:
 		return primitive("number")
 	case reflect.String:
 		return primitive("string")
@@ -224,6 +286,44 @@ func serializeTypeAsPropertyType(
 	}
 }
 
+// serializeUnion describes a union, such as Union2, as oneOf its variants.
+func serializeUnion(
+	variants []reflect.Type, indicatePlain bool, propType propertyType, namer TokenNamer,
+) (schema.TypeSpec, error) {
+	var spec schema.TypeSpec
+	for _, t := range variants {
+		v, err := serializeTypeAsPropertyType(t, indicatePlain, nil, propType, namer)
+		if err != nil {
+			return schema.TypeSpec{}, err
+		}
+		spec.OneOf = append(spec.OneOf, v)
+	}
+
+	// Languages without unions use the primitive type shared by all variants, if there
+	// is one.
+	switch spec.Type = spec.OneOf[0].Type; spec.Type {
+	case "boolean", "integer", "number", "string":
+		for _, v := range spec.OneOf[1:] {
+			if v.Type != spec.Type {
+				spec.Type = ""
+			}
+		}
+	default:
+		spec.Type = ""
+	}
+
+	if property, values, ok := ende.Discriminator(variants); ok {
+		spec.Discriminator = &schema.DiscriminatorSpec{
+			PropertyName: property,
+			Mapping:      map[string]string{},
+		}
+		for i, v := range spec.OneOf {
+			spec.Discriminator.Mapping[values[i]] = v.Ref
+		}
+	}
+	return spec, nil
+}
+
 // underlyingType find the non-inputty, non-ptr type of t. It returns the underlying type
 // and if t was an Inputty or Outputty type.
 func underlyingType(t reflect.Type) (reflect.Type, bool, error) {
@@ -272,7 +372,7 @@ func underlyingType(t reflect.Type) (reflect.Type, bool, error) {
 	return t, isOutputType || isInputType, nil
 }
 
-func propertyListFromType(typ reflect.Type, indicatePlain bool, propType propertyType) (
+func propertyListFromType(typ reflect.Type, indicatePlain bool, propType propertyType, namer TokenNamer) (
 	props map[string]schema.PropertySpec, required []string, err error,
 ) {
 	for typ.Kind() == reflect.Pointer {
@@ -280,6 +380,10 @@ func propertyListFromType(typ reflect.Type, indicatePlain bool, propType propert
 	}
 	props = map[string]schema.PropertySpec{}
 	annotations := getAnnotated(typ)
+	// The field that declared each alias, so that aliases can be checked against the
+	// names of every field.
+	aliasOf := map[string]string{}
+	names := map[string]bool{}
 
 	for _, field := range reflect.VisibleFields(typ) {
 		fieldType := field.Type
@@ -293,20 +397,33 @@ func propertyListFromType(typ reflect.Type, indicatePlain bool, propType propert
 		if tags.Internal {
 			continue
 		}
-		serialized, err := serializeTypeAsPropertyType(fieldType, indicatePlain, tags.ExplicitRef, propType)
+		names[tags.Name] = true
+		serialized, err := serializeTypeAsPropertyType(fieldType, indicatePlain, tags.ExplicitRef, propType, namer)
 		if err != nil {
 			return nil, nil, fmt.Errorf("invalid type '%s' on '%s.%s': %w", fieldType, typ, field.Name, err)
 		}
-		if !tags.Optional {
+		// An auto-named input is always optional, and changing it replaces the
+		// resource.
+		_, isAutoNamed := annotations.AutoNames[tags.Name]
+		isAutoNamed = isAutoNamed && propType == inputType
+		if !tags.Optional && !isAutoNamed {
 			required = append(required, tags.Name)
 		}
+		for _, alias := range tags.Aliases {
+			if other, ok := aliasOf[alias]; ok {
+				return nil, nil, fmt.Errorf("invalid field '%s' on '%s': alias '%s' is already an alias of '%s'",
+					field.Name, typ, alias, other)
+			}
+			aliasOf[alias] = tags.Name
+		}
 		spec := &schema.PropertySpec{
 			TypeSpec:           serialized,
 			Secret:             tags.Secret,
-			ReplaceOnChanges:   tags.ReplaceOnChanges,
-			Description:        annotations.Descriptions[tags.Name],
+			ReplaceOnChanges:   tags.ReplaceOnChanges || isAutoNamed,
+			Description:        withRange(annotations.Descriptions[tags.Name], fieldType),
 			Default:            annotations.Defaults[tags.Name],
 			DeprecationMessage: annotations.DeprecationMessages[tags.Name],
+			Language:           languageSpec(annotations, tags.Name),
 		}
 		if envs := annotations.DefaultEnvs[tags.Name]; len(envs) > 0 {
 			spec.DefaultInfo = &schema.DefaultSpec{
@@ -314,18 +431,104 @@ func propertyListFromType(typ reflect.Type, indicatePlain bool, propType propert
 			}
 		}
 		props[tags.Name] = *spec
+
+		// Aliases are described as optional copies of the input, so that programs
+		// written against the old name keep working. Outputs are only ever set under
+		// the current name.
+		if propType != inputType {
+			continue
+		}
+		for _, alias := range tags.Aliases {
+			aliasSpec := schema.PropertySpec{
+				TypeSpec:           spec.TypeSpec,
+				Secret:             spec.Secret,
+				ReplaceOnChanges:   spec.ReplaceOnChanges,
+				Description:        spec.Description,
+				DeprecationMessage: fmt.Sprintf("%s has been renamed to %s.", alias, tags.Name),
+			}
+			props[alias] = aliasSpec
+		}
+	}
+	for _, alias := range slices.Sorted(maps.Keys(aliasOf)) {
+		if names[alias] {
+			return nil, nil, fmt.Errorf("invalid alias '%s' of '%s' on '%s': '%s' is the name of another field",
+				alias, aliasOf[alias], typ, alias)
+		}
 	}
 	return props, required, nil
 }
 
+// withExamples adds examples to description, in the format that the Pulumi registry
+// expects.
+func withExamples(description string, examples []introspect.Example) string {
+	if len(examples) == 0 {
+		return description
+	}
+	var b strings.Builder
+	if description != "" {
+		b.WriteString(description)
+		b.WriteString("\n\n")
+	}
+	b.WriteString("{{% examples %}}\n## Example Usage\n")
+	for _, example := range examples {
+		fmt.Fprintf(&b, "{{%% example %%}}\n### %s\n", example.Title)
+		for _, lang := range introspect.ExampleLanguages {
+			program, ok := example.Programs[lang]
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&b, "\n```%s\n%s\n```\n", lang, strings.Trim(program, "\n"))
+		}
+		b.WriteString("{{% /example %}}\n")
+	}
+	b.WriteString("{{% /examples %}}")
+	return b.String()
+}
+
+// withRange adds the range of values of t to description, if t is a numeric type with a
+// narrower range than the integer or number it is described as.
+func withRange(description string, t reflect.Type) string {
+	for {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		} else if inner, ok := ende.Unwrap(t); ok {
+			t = inner
+		} else {
+			break
+		}
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int64, reflect.Float64:
+		return description
+	}
+	if _, ok, _ := isEnum(t, nil); ok || ende.IsText(t) {
+		return description
+	}
+	lo, hi, ok := ende.NumberRange(t)
+	if !ok {
+		return description
+	}
+	note := fmt.Sprintf("Must be between %s and %s.", lo, hi)
+	if description == "" {
+		return note
+	}
+	return description + "\n\n" + note
+}
+
 func resourceReferenceToken(
-	t reflect.Type, extTag *introspect.ExplicitType, allowMissingExtType bool,
+	t reflect.Type, extTag *introspect.ExplicitType, allowMissingExtType bool, namer TokenNamer,
 ) (schema.TypeSpec, bool, error) {
 	ptrT := reflect.PointerTo(t)
 	implements := func(typ reflect.Type) bool {
 		return t.Implements(typ) || ptrT.Implements(typ)
 	}
 	switch {
+	case implements(reflect.TypeFor[resourceRef]()):
+		// A typed reference to a resource, such as ResourceRef[R]
+		tk, err := reflect.New(t).Elem().Interface().(resourceRef).resourceToken(namer)
+		return schema.TypeSpec{
+			Ref: "#/resources/" + tk.String(),
+		}, true, err
 	// This handles both components and resources
 	case implements(reflect.TypeOf(new(sch.Resource)).Elem()):
 		tk, err := reflect.New(t).Elem().Interface().(sch.Resource).GetToken()
@@ -349,7 +552,9 @@ func resourceReferenceToken(
 	}
 }
 
-func structReferenceToken(t reflect.Type, extTag *introspect.ExplicitType) (schema.TypeSpec, bool, error) {
+func structReferenceToken(
+	t reflect.Type, extTag *introspect.ExplicitType, namer TokenNamer,
+) (schema.TypeSpec, bool, error) {
 	if t.Kind() == reflect.Struct && extTag != nil {
 		if extTag.Pkg != "" {
 			return schema.TypeSpec{
@@ -368,7 +573,7 @@ func structReferenceToken(t reflect.Type, extTag *introspect.ExplicitType) (sche
 		return schema.TypeSpec{}, false, nil
 	}
 
-	tk, err := getTokenOf(t, nil)
+	tk, err := getTokenOf(t, namer, nil)
 	if err != nil {
 		return schema.TypeSpec{}, true, err
 	}
@@ -384,9 +589,10 @@ func schemaNameForType(t reflect.Kind) string {
 		return "string"
 	case reflect.Bool:
 		return "boolean"
-	case reflect.Float64:
+	case reflect.Float32, reflect.Float64:
 		return "number"
-	case reflect.Int:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		return "integer"
 	default:
 		panic(fmt.Sprintf("unknown primitive type: %s", t))
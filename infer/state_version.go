@@ -0,0 +1,203 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+
+	"github.com/pulumi/pulumi-go-provider/infer/internal/ende"
+)
+
+// stateVersionKeyName is the key under "__internal" that holds the version of a
+// resource's state.
+const stateVersionKeyName = "pulumi-go-provider-state-version"
+
+// StateVersionMigration upgrades the state of a resource from one version to the next.
+//
+// To create a StateVersionMigration, use [StateVersion].
+type StateVersionMigration interface {
+	version() int
+	oldShape() reflect.Type
+	newShape() reflect.Type
+	migrate(ctx context.Context, state property.Map) (property.Map, error)
+}
+
+// StateVersion creates the migration of a resource's state from version n-1, of shape
+// Old, to version n, of shape New.
+//
+// If Old is [property.Map], f receives the state as it was stored.
+//
+// Example:
+//
+//	func (*MyResource) StateVersions(context.Context) []infer.StateVersionMigration {
+//		return []infer.StateVersionMigration{
+//			infer.StateVersion(1, func(_ context.Context, v0 MyStateV0) (MyStateV1, error) {
+//				return MyStateV1{Size: v0.SizeMb / 1024}, nil
+//			}),
+//			infer.StateVersion(2, func(_ context.Context, v1 MyStateV1) (MyState, error) {
+//				return MyState{SizeGb: v1.Size}, nil
+//			}),
+//		}
+//	}
+func StateVersion[Old, New any](n int, f func(context.Context, Old) (New, error)) StateVersionMigration {
+	return stateVersion[Old, New]{n, f}
+}
+
+// CustomStateVersions describes a resource whose state is versioned.
+//
+// The state returned by each operation is stamped with the current version, which is
+// the number of migrations returned by StateVersions. State that was stored before the
+// resource declared versions has version 0.
+//
+// When state is read, it is upgraded through the migrations that follow its version,
+// in order. Unlike [CustomStateMigrations], which tries each migration until one
+// applies, the version recorded in state decides which migrations run. If a resource
+// implements both interfaces, only CustomStateVersions is used.
+type CustomStateVersions interface {
+	// StateVersions is the chain of migrations for the resource's state.
+	//
+	// The i-th migration (starting at 0) must be created with StateVersion(i+1, ...),
+	// and the last migration must produce the resource's state type.
+	StateVersions(ctx context.Context) []StateVersionMigration
+}
+
+type stateVersion[Old, New any] struct {
+	n int
+	f func(context.Context, Old) (New, error)
+}
+
+func (m stateVersion[Old, New]) version() int         { return m.n }
+func (stateVersion[Old, New]) oldShape() reflect.Type { return reflect.TypeFor[Old]() }
+func (stateVersion[Old, New]) newShape() reflect.Type { return reflect.TypeFor[New]() }
+
+func (m stateVersion[Old, New]) migrate(ctx context.Context, state property.Map) (property.Map, error) {
+	var old Old
+	var enc ende.Encoder
+	if raw, ok := any(&old).(*property.Map); ok {
+		*raw = state
+	} else {
+		var err error
+		enc, err = ende.DecodeAny(state, &old)
+		if err != nil {
+			return property.Map{}, fmt.Errorf("decoding state at version %d: %w", m.n-1, err)
+		}
+	}
+
+	upgraded, err := m.f(ctx, old)
+	if err != nil {
+		return property.Map{}, err
+	}
+	if raw, ok := any(upgraded).(property.Map); ok {
+		return raw, nil
+	}
+
+	// Secrets are kept for the fields that did not move between versions.
+	m2, mErr := enc.Encode(upgraded)
+	if mErr != nil {
+		return property.Map{}, fmt.Errorf("encoding state at version %d: %w", m.n, mErr)
+	}
+	return resource.FromResourcePropertyValue(resource.NewProperty(m2)).AsMap(), nil
+}
+
+// stateVersions returns the validated state migrations of r, if r implements
+// [CustomStateVersions].
+func stateVersions[O any](ctx context.Context, r any) ([]StateVersionMigration, bool, error) {
+	v, ok := r.(CustomStateVersions)
+	if !ok {
+		return nil, false, nil
+	}
+	migrations := v.StateVersions(ctx)
+
+	rawType := reflect.TypeFor[property.Map]()
+	for i, m := range migrations {
+		if m.version() != i+1 {
+			return nil, true, fmt.Errorf("state migration %d must migrate to version %d, found version %d",
+				i, i+1, m.version())
+		}
+		if i > 0 && m.oldShape() != rawType && m.oldShape() != migrations[i-1].newShape() {
+			return nil, true, fmt.Errorf("state migration to version %d expects %s, but version %d is %s",
+				m.version(), m.oldShape(), i, migrations[i-1].newShape())
+		}
+	}
+	if l := len(migrations); l > 0 {
+		last := migrations[l-1].newShape()
+		if last != rawType && last != reflect.TypeFor[O]() {
+			return nil, true, fmt.Errorf("state migration to version %d must produce %s, found %s",
+				l, reflect.TypeFor[O](), last)
+		}
+	}
+	return migrations, true, nil
+}
+
+// upgradeState removes the version stamp from state and runs the migrations that follow
+// it.
+func upgradeState(
+	ctx context.Context, migrations []StateVersionMigration, state property.Map,
+) (property.Map, error) {
+	version, state := splitStateVersion(state)
+	if version < 0 || version > len(migrations) {
+		return property.Map{}, fmt.Errorf("state has version %d, but only versions up to %d are known",
+			version, len(migrations))
+	}
+
+	for _, m := range migrations[version:] {
+		var err error
+		state, err = m.migrate(ctx, state)
+		if err != nil {
+			return property.Map{}, fmt.Errorf("migrating state to version %d: %w", m.version(), err)
+		}
+	}
+	return state, nil
+}
+
+// splitStateVersion returns the version stamped into state, and state without the stamp.
+func splitStateVersion(state property.Map) (int, property.Map) {
+	internal, ok := state.GetOk("__internal")
+	if !ok || !internal.IsMap() {
+		return 0, state
+	}
+	v, ok := internal.AsMap().GetOk(stateVersionKeyName)
+	if !ok || !v.IsNumber() {
+		return 0, state
+	}
+
+	rest := internal.AsMap().Delete(stateVersionKeyName)
+	if rest.Len() == 0 {
+		return int(v.AsNumber()), state.Delete("__internal")
+	}
+	return int(v.AsNumber()), state.Set("__internal", property.WithGoValue(internal, rest))
+}
+
+// stampStateVersion records the current state version of r in state, if r declares
+// [CustomStateVersions].
+func stampStateVersion[O any](ctx context.Context, r any, state resource.PropertyMap) error {
+	migrations, ok, err := stateVersions[O](ctx, r)
+	if !ok || err != nil {
+		return err
+	}
+
+	internal := state["__internal"]
+	if !internal.IsObject() {
+		internal = resource.NewObjectProperty(resource.PropertyMap{})
+		state["__internal"] = internal
+	}
+	internal.ObjectValue()[stateVersionKeyName] = resource.NewNumberProperty(float64(len(migrations)))
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticStateVersions []StateVersionMigration
+
+func (s staticStateVersions) StateVersions(context.Context) []StateVersionMigration { return s }
+
+func TestStateVersionsValidation(t *testing.T) {
+	t.Parallel()
+
+	type v0 struct{}
+	type v1 struct{}
+	type v2 struct{}
+
+	tests := []struct {
+		name       string
+		migrations staticStateVersions
+		err        string
+	}{
+		{
+			name: "valid",
+			migrations: staticStateVersions{
+				StateVersion(1, func(context.Context, v0) (v1, error) { return v1{}, nil }),
+				StateVersion(2, func(context.Context, v1) (v2, error) { return v2{}, nil }),
+			},
+		},
+		{
+			name: "raw",
+			migrations: staticStateVersions{
+				StateVersion(1, func(context.Context, v0) (v1, error) { return v1{}, nil }),
+				StateVersion(2, func(context.Context, property.Map) (v2, error) { return v2{}, nil }),
+			},
+		},
+		{
+			name: "out-of-order",
+			migrations: staticStateVersions{
+				StateVersion(2, func(context.Context, v1) (v2, error) { return v2{}, nil }),
+			},
+			err: "state migration 0 must migrate to version 1, found version 2",
+		},
+		{
+			name: "broken-chain",
+			migrations: staticStateVersions{
+				StateVersion(1, func(context.Context, v0) (v1, error) { return v1{}, nil }),
+				StateVersion(2, func(context.Context, v0) (v2, error) { return v2{}, nil }),
+			},
+			err: "state migration to version 2 expects infer.v0, but version 1 is infer.v1",
+		},
+		{
+			name: "wrong-result",
+			migrations: staticStateVersions{
+				StateVersion(1, func(context.Context, v0) (v1, error) { return v1{}, nil }),
+			},
+			err: "state migration to version 1 must produce infer.v2, found infer.v1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			migrations, ok, err := stateVersions[v2](t.Context(), tt.migrations)
+			assert.True(t, ok)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, migrations, len(tt.migrations))
+		})
+	}
+}
+
+func TestSplitStateVersion(t *testing.T) {
+	t.Parallel()
+
+	version, state := splitStateVersion(property.NewMap(map[string]property.Value{
+		"a": property.New("b"),
+		"__internal": property.New(map[string]property.Value{
+			stateVersionKeyName: property.New(3.0),
+			"other":             property.New(true),
+		}),
+	}))
+	assert.Equal(t, 3, version)
+	assert.Equal(t, property.NewMap(map[string]property.Value{
+		"a": property.New("b"),
+		"__internal": property.New(map[string]property.Value{
+			"other": property.New(true),
+		}),
+	}), state)
+
+	version, state = splitStateVersion(property.NewMap(map[string]property.Value{
+		"a": property.New("b"),
+	}))
+	assert.Equal(t, 0, version)
+	assert.Equal(t, property.NewMap(map[string]property.Value{
+		"a": property.New("b"),
+	}), state)
+}
@@ -0,0 +1,223 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi-go-provider/integration"
+)
+
+type (
+	Renamed     struct{}
+	RenamedArgs struct {
+		Name string `pulumi:"name" provider:"alias=bucketName,replaceOnChanges"`
+	}
+	RenamedState struct {
+		RenamedArgs
+		Arn string `pulumi:"arn"`
+	}
+)
+
+func (*Renamed) Create(
+	_ context.Context, req infer.CreateRequest[RenamedArgs],
+) (infer.CreateResponse[RenamedState], error) {
+	return infer.CreateResponse[RenamedState]{
+		ID:     req.Inputs.Name,
+		Output: RenamedState{RenamedArgs: req.Inputs, Arn: "arn:" + req.Inputs.Name},
+	}, nil
+}
+
+func (*Renamed) Update(
+	_ context.Context, req infer.UpdateRequest[RenamedArgs, RenamedState],
+) (infer.UpdateResponse[RenamedState], error) {
+	return infer.UpdateResponse[RenamedState]{Output: req.State}, nil
+}
+
+func TestAliasesSchema(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			InputProperties map[string]json.RawMessage `json:"inputProperties"`
+			Properties      map[string]json.RawMessage `json:"properties"`
+			RequiredInputs  []string                   `json:"requiredInputs"`
+		} `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	res := spec.Resources["test:index:Renamed"]
+	assert.JSONEq(t, `{"type": "string", "replaceOnChanges": true}`, string(res.InputProperties["name"]))
+	assert.JSONEq(t, `{
+		"type": "string",
+		"replaceOnChanges": true,
+		"deprecationMessage": "bucketName has been renamed to name."
+	}`, string(res.InputProperties["bucketName"]))
+	// The alias is optional, so that a program may set either name.
+	assert.Equal(t, []string{"name"}, res.RequiredInputs)
+	assert.NotContains(t, res.Properties, "bucketName")
+}
+
+func TestAliases(t *testing.T) {
+	t.Parallel()
+
+	oldState := property.NewMap(map[string]property.Value{
+		"bucketName": property.New("b"),
+		"arn":        property.New("arn:b"),
+	})
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn: urn("Renamed", "create"),
+			Properties: property.NewMap(map[string]property.Value{
+				"bucketName": property.New("b"),
+			}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, property.NewMap(map[string]property.Value{
+			"name": property.New("b"),
+			"arn":  property.New("arn:b"),
+		}), resp.Properties)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Check(p.CheckRequest{
+			Urn: urn("Renamed", "check"),
+			Inputs: property.NewMap(map[string]property.Value{
+				"bucketName": property.New("b"),
+			}),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Failures)
+		assert.Equal(t, property.NewMap(map[string]property.Value{
+			"name": property.New("b"),
+		}), resp.Inputs)
+	})
+
+	t.Run("diff-renamed", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Diff(p.DiffRequest{
+			ID:     "b",
+			Urn:    urn("Renamed", "diff"),
+			State:  oldState,
+			Inputs: property.NewMap(map[string]property.Value{"name": property.New("b")}),
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.HasChanges)
+	})
+
+	t.Run("diff-changed", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Diff(p.DiffRequest{
+			ID:     "b",
+			Urn:    urn("Renamed", "diff"),
+			State:  oldState,
+			Inputs: property.NewMap(map[string]property.Value{"name": property.New("c")}),
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.HasChanges)
+		assert.Equal(t, map[string]p.PropertyDiff{
+			"name": {Kind: p.UpdateReplace},
+		}, resp.DetailedDiff)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Update(p.UpdateRequest{
+			ID:     "b",
+			Urn:    urn("Renamed", "update"),
+			State:  oldState,
+			Inputs: property.NewMap(map[string]property.Value{"name": property.New("b")}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, property.NewMap(map[string]property.Value{
+			"name": property.New("b"),
+			"arn":  property.New("arn:b"),
+		}), resp.Properties)
+	})
+}
+
+type (
+	CollidingAlias     struct{}
+	CollidingAliasArgs struct {
+		Name  *string `pulumi:"name,optional" provider:"alias=title"`
+		Title *string `pulumi:"title,optional"`
+	}
+	DuplicateAlias     struct{}
+	DuplicateAliasArgs struct {
+		Prefix *string `pulumi:"prefix,optional" provider:"alias=start"`
+		Suffix *string `pulumi:"suffix,optional" provider:"alias=start"`
+	}
+)
+
+func (*CollidingAlias) Create(
+	context.Context, infer.CreateRequest[CollidingAliasArgs],
+) (infer.CreateResponse[CollidingAliasArgs], error) {
+	panic("unimplemented")
+}
+
+func (*DuplicateAlias) Create(
+	context.Context, infer.CreateRequest[DuplicateAliasArgs],
+) (infer.CreateResponse[DuplicateAliasArgs], error) {
+	panic("unimplemented")
+}
+
+func TestAliasesInvalid(t *testing.T) {
+	t.Parallel()
+
+	getSchema := func(t *testing.T, r infer.InferredResource) error {
+		s, err := integration.NewServer(t.Context(), "test", semver.MustParse("1.0.0"),
+			integration.WithProvider(infer.Provider(infer.Options{
+				Resources: []infer.InferredResource{r},
+				ModuleMap: map[tokens.ModuleName]tokens.ModuleName{"tests": "index"},
+			})))
+		require.NoError(t, err)
+		_, err = s.GetSchema(p.GetSchemaRequest{})
+		return err
+	}
+
+	t.Run("colliding", func(t *testing.T) {
+		t.Parallel()
+		err := getSchema(t, infer.Resource(&CollidingAlias{}))
+		assert.ErrorContains(t, err, "invalid alias 'title' of 'name'")
+		assert.ErrorContains(t, err, "'title' is the name of another field")
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		t.Parallel()
+		err := getSchema(t, infer.Resource(&DuplicateAlias{}))
+		assert.ErrorContains(t, err, "alias 'start' is already an alias of 'prefix'")
+	})
+}
@@ -0,0 +1,183 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type (
+	AutoNamed     struct{}
+	AutoNamedArgs struct {
+		Name string `pulumi:"name,optional"`
+		Size int    `pulumi:"size,optional"`
+	}
+)
+
+func (a *AutoNamedArgs) Annotate(an infer.Annotator) {
+	an.SetAutoName(&a.Name, "${name}-${alphanum(6)}", 16)
+}
+
+func (*AutoNamed) Create(
+	_ context.Context, req infer.CreateRequest[AutoNamedArgs],
+) (infer.CreateResponse[AutoNamedArgs], error) {
+	return infer.CreateResponse[AutoNamedArgs]{ID: req.Inputs.Name, Output: req.Inputs}, nil
+}
+
+func (*AutoNamed) Update(
+	_ context.Context, req infer.UpdateRequest[AutoNamedArgs, AutoNamedArgs],
+) (infer.UpdateResponse[AutoNamedArgs], error) {
+	return infer.UpdateResponse[AutoNamedArgs]{Output: req.Inputs}, nil
+}
+
+func TestAutoNameSchema(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			InputProperties map[string]json.RawMessage `json:"inputProperties"`
+			RequiredInputs  []string                   `json:"requiredInputs"`
+		} `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	res := spec.Resources["test:index:AutoNamed"]
+	assert.JSONEq(t, `{"type": "string", "replaceOnChanges": true}`, string(res.InputProperties["name"]))
+	assert.Empty(t, res.RequiredInputs)
+}
+
+func TestAutoNameSupportsConfiguration(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, infer.Provider(infer.Options{
+		Resources: []infer.InferredResource{infer.Resource(&AutoNamed{})},
+	}).SupportsAutonamingConfiguration)
+	assert.False(t, infer.Provider(infer.Options{
+		Resources: []infer.InferredResource{infer.Resource(&Echo{})},
+	}).SupportsAutonamingConfiguration)
+}
+
+func TestAutoName(t *testing.T) {
+	t.Parallel()
+
+	seed := []byte("a random seed from the engine")
+	check := func(t *testing.T, req p.CheckRequest) p.CheckResponse {
+		req.Urn = urn("AutoNamed", "my-bucket-with-a-long-name")
+		req.RandomSeed = seed
+		resp, err := provider(t).Check(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("generated", func(t *testing.T) {
+		t.Parallel()
+
+		first := check(t, p.CheckRequest{})
+		require.Empty(t, first.Failures)
+		name := first.Inputs.Get("name").AsString()
+		assert.Len(t, name, 16)
+		assert.Regexp(t, `^my-bucket-[a-z0-9]{6}$`, name)
+
+		// The same seed generates the same name.
+		assert.Equal(t, name, check(t, p.CheckRequest{}).Inputs.Get("name").AsString())
+	})
+
+	t.Run("set", func(t *testing.T) {
+		t.Parallel()
+
+		resp := check(t, p.CheckRequest{
+			Inputs: property.NewMap(map[string]property.Value{"name": property.New("explicit")}),
+		})
+		assert.Equal(t, "explicit", resp.Inputs.Get("name").AsString())
+	})
+
+	t.Run("preserved", func(t *testing.T) {
+		t.Parallel()
+
+		resp := check(t, p.CheckRequest{
+			State: property.NewMap(map[string]property.Value{"name": property.New("old-name")}),
+			Inputs: property.NewMap(map[string]property.Value{
+				"size": property.New(2.0),
+			}),
+		})
+		assert.Equal(t, "old-name", resp.Inputs.Get("name").AsString())
+	})
+
+	t.Run("propose", func(t *testing.T) {
+		t.Parallel()
+
+		resp := check(t, p.CheckRequest{Autonaming: &p.AutonamingOptions{
+			ProposedName: "proposed",
+			Mode:         p.AutonamingModePropose,
+		}})
+		assert.Equal(t, "proposed", resp.Inputs.Get("name").AsString())
+
+		// A proposed name that is too long is not used.
+		resp = check(t, p.CheckRequest{Autonaming: &p.AutonamingOptions{
+			ProposedName: "a-proposed-name-that-is-too-long",
+			Mode:         p.AutonamingModePropose,
+		}})
+		assert.Regexp(t, `^my-bucket-[a-z0-9]{6}$`, resp.Inputs.Get("name").AsString())
+	})
+
+	t.Run("enforce", func(t *testing.T) {
+		t.Parallel()
+
+		resp := check(t, p.CheckRequest{Autonaming: &p.AutonamingOptions{
+			ProposedName: "a-proposed-name-that-is-too-long",
+			Mode:         p.AutonamingModeEnforce,
+		}})
+		assert.Equal(t, "a-proposed-name-that-is-too-long", resp.Inputs.Get("name").AsString())
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		t.Parallel()
+
+		resp := check(t, p.CheckRequest{Autonaming: &p.AutonamingOptions{
+			Mode: p.AutonamingModeDisable,
+		}})
+		assert.Equal(t, []p.CheckFailure{{
+			Property: "name",
+			Reason:   "name is required because autonaming is disabled",
+		}}, resp.Failures)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Diff(p.DiffRequest{
+			ID:     "old-name",
+			Urn:    urn("AutoNamed", "my-bucket"),
+			State:  property.NewMap(map[string]property.Value{"name": property.New("old-name")}),
+			Inputs: property.NewMap(map[string]property.Value{"name": property.New("new-name")}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]p.PropertyDiff{
+			"name": {Kind: p.UpdateReplace},
+		}, resp.DetailedDiff)
+	})
+}
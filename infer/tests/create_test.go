@@ -15,12 +15,19 @@
 package tests
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/property"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 
 	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
 )
 
 func TestCreate(t *testing.T) {
@@ -209,3 +216,203 @@ func TestCreate(t *testing.T) {
 		}), resp.Properties)
 	})
 }
+
+func TestCreateIsUnknown(t *testing.T) {
+	t.Parallel()
+
+	r := NewMockTestResource[WiredInputs, WiredOutputs](gomock.NewController(t))
+	r.EXPECT().Annotate(gomock.Any()).DoAndReturn(func(a infer.Annotator) {
+		a.SetToken("index", "Unknowns")
+	}).AnyTimes()
+	r.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(
+		_ context.Context, req infer.CreateRequest[WiredInputs],
+	) (infer.CreateResponse[WiredOutputs], error) {
+		assert.True(t, req.IsUnknown(&req.Inputs.String))
+		assert.False(t, req.IsUnknown(&req.Inputs.Int))
+		assert.True(t, req.IsUnknown(&req.Inputs))
+		assert.Equal(t, 4, req.Inputs.Int)
+		return infer.CreateResponse[WiredOutputs]{ID: "id"}, nil
+	})
+
+	prov, err := infer.NewProviderBuilder().WithResources(infer.Resource(r)).Build()
+	require.NoError(t, err)
+
+	_, err = prov.Create(t.Context(), p.CreateRequest{
+		Urn: urn("Unknowns", "preview"),
+		Properties: property.NewMap(map[string]property.Value{
+			"string": property.New(property.Computed),
+			"int":    property.New(4.0),
+		}),
+		DryRun: true,
+	})
+	assert.NoError(t, err)
+}
+
+type (
+	NestedUnknownInputs struct {
+		Nested NestedUnknown   `pulumi:"nested"`
+		Ptr    *NestedUnknown  `pulumi:"ptr,optional"`
+		List   []NestedUnknown `pulumi:"list"`
+		Known  []NestedUnknown `pulumi:"known"`
+		Whole  *NestedUnknown  `pulumi:"whole,optional"`
+	}
+	NestedUnknown struct {
+		F string `pulumi:"f"`
+		G string `pulumi:"g"`
+	}
+)
+
+func TestCreateIsUnknownNested(t *testing.T) {
+	t.Parallel()
+
+	r := NewMockTestResource[NestedUnknownInputs, WiredOutputs](gomock.NewController(t))
+	r.EXPECT().Annotate(gomock.Any()).DoAndReturn(func(a infer.Annotator) {
+		a.SetToken("index", "NestedUnknowns")
+	}).AnyTimes()
+	r.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(
+		_ context.Context, req infer.CreateRequest[NestedUnknownInputs],
+	) (infer.CreateResponse[WiredOutputs], error) {
+		in := &req.Inputs
+		assert.True(t, req.IsUnknown(&in.Nested))
+		assert.True(t, req.IsUnknown(&in.Nested.F))
+		assert.False(t, req.IsUnknown(&in.Nested.G))
+
+		require.NotNil(t, in.Ptr)
+		assert.True(t, req.IsUnknown(in.Ptr))
+		assert.False(t, req.IsUnknown(&in.Ptr.F))
+		assert.True(t, req.IsUnknown(&in.Ptr.G))
+
+		// An unknown nested in a list makes the list unknown.
+		require.Len(t, in.List, 2)
+		assert.True(t, req.IsUnknown(&in.List))
+		assert.False(t, req.IsUnknown(&in.List[0]))
+		assert.True(t, req.IsUnknown(&in.List[1]))
+		assert.True(t, req.IsUnknown(&in.List[1].F))
+		assert.False(t, req.IsUnknown(&in.List[1].G))
+		assert.False(t, req.IsUnknown(&in.Known))
+
+		// Values within an unknown value are unknown.
+		assert.True(t, req.IsUnknown(&in.Whole))
+		require.NotNil(t, in.Whole)
+		assert.True(t, req.IsUnknown(&in.Whole.F))
+
+		// Pointers outside of the inputs are never unknown.
+		var other NestedUnknown
+		assert.False(t, req.IsUnknown(&other.F))
+		assert.False(t, req.IsUnknown(nil))
+		return infer.CreateResponse[WiredOutputs]{ID: "id"}, nil
+	})
+
+	prov, err := infer.NewProviderBuilder().WithResources(infer.Resource(r)).Build()
+	require.NoError(t, err)
+
+	obj := func(f, g property.Value) property.Value {
+		return property.New(map[string]property.Value{"f": f, "g": g})
+	}
+	computed := property.New(property.Computed)
+	_, err = prov.Create(t.Context(), p.CreateRequest{
+		Urn: urn("NestedUnknowns", "preview"),
+		Properties: property.NewMap(map[string]property.Value{
+			"nested": obj(computed, property.New("g")),
+			"ptr":    obj(property.New("f"), computed),
+			"list": property.New([]property.Value{
+				obj(property.New("f"), property.New("g")),
+				obj(computed, property.New("g")),
+			}),
+			"known": property.New([]property.Value{obj(property.New("f"), property.New("g"))}),
+			"whole": computed,
+		}),
+		DryRun: true,
+	})
+	assert.NoError(t, err)
+}
+
+func TestCreateDefaultTimeout(t *testing.T) {
+	t.Parallel()
+
+	test := func(t *testing.T, engineTimeout float64, expected time.Duration) {
+		r := NewMockTestResource[WiredInputs, WiredOutputs](gomock.NewController(t))
+		r.EXPECT().Annotate(gomock.Any()).DoAndReturn(func(a infer.Annotator) {
+			a.SetToken("index", "Timeouts")
+			a.SetDefaultTimeouts(time.Hour, 0, 0)
+		}).AnyTimes()
+		r.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(
+			ctx context.Context, req infer.CreateRequest[WiredInputs],
+		) (infer.CreateResponse[WiredOutputs], error) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			assert.Equal(t, deadline, req.Deadline)
+			assert.WithinDuration(t, time.Now().Add(expected), req.Deadline, time.Minute)
+			return infer.CreateResponse[WiredOutputs]{ID: "id"}, nil
+		})
+
+		prov, err := infer.NewProviderBuilder().WithResources(infer.Resource(r)).Build()
+		require.NoError(t, err)
+
+		_, err = prov.Create(t.Context(), p.CreateRequest{
+			Urn: urn("Timeouts", "create"),
+			Properties: property.NewMap(map[string]property.Value{
+				"string": property.New("foo"),
+				"int":    property.New(1.0),
+			}),
+			Timeout: engineTimeout,
+		})
+		assert.NoError(t, err)
+	}
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		test(t, 0, time.Hour)
+	})
+
+	t.Run("engine", func(t *testing.T) {
+		t.Parallel()
+		test(t, (10 * time.Minute).Seconds(), 10*time.Minute)
+	})
+}
+
+type RetryConfig struct {
+	Region string `pulumi:"region,optional"`
+}
+
+func (RetryConfig) RetryPolicy() infer.RetryPolicy {
+	return infer.RetryPolicy{
+		Idempotent: []infer.Operation{infer.OperationCreate},
+		MinDelay:   time.Millisecond,
+		Retryable: func(err error) bool {
+			return strings.Contains(err.Error(), "503")
+		},
+	}
+}
+
+func TestCreateRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	r := NewMockTestResource[WiredInputs, WiredOutputs](gomock.NewController(t))
+	r.EXPECT().Annotate(gomock.Any()).DoAndReturn(func(a infer.Annotator) {
+		a.SetToken("index", "Retried")
+	}).AnyTimes()
+	gomock.InOrder(
+		r.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Return(infer.CreateResponse[WiredOutputs]{}, errors.New("503: service unavailable")).
+			Times(2),
+		r.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Return(infer.CreateResponse[WiredOutputs]{ID: "id"}, nil),
+	)
+
+	prov, err := infer.NewProviderBuilder().
+		WithResources(infer.Resource(r)).
+		WithConfig(infer.Config(RetryConfig{})).
+		Build()
+	require.NoError(t, err)
+
+	resp, err := prov.Create(t.Context(), p.CreateRequest{
+		Urn: urn("Retried", "create"),
+		Properties: property.NewMap(map[string]property.Value{
+			"string": property.New("foo"),
+			"int":    property.New(1.0),
+		}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "id", resp.ID)
+}
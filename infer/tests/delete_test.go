@@ -0,0 +1,76 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+func TestDeleteDefaultTimeout(t *testing.T) {
+	t.Parallel()
+
+	test := func(t *testing.T, engineTimeout float64, expected time.Duration) {
+		r := NewMockTestResource[WiredInputs, WiredOutputs](gomock.NewController(t))
+		r.EXPECT().Annotate(gomock.Any()).DoAndReturn(func(a infer.Annotator) {
+			a.SetToken("index", "Timeouts")
+			a.SetDefaultTimeouts(0, 0, time.Hour)
+		}).AnyTimes()
+		r.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(func(
+			ctx context.Context, req infer.DeleteRequest[WiredOutputs],
+		) (infer.DeleteResponse, error) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			assert.Equal(t, deadline, req.Deadline)
+			assert.WithinDuration(t, time.Now().Add(expected), req.Deadline, time.Minute)
+			assert.Equal(t, "str+", req.State.StringPlus)
+			return infer.DeleteResponse{}, nil
+		})
+
+		prov, err := infer.NewProviderBuilder().WithResources(infer.Resource(r)).Build()
+		require.NoError(t, err)
+
+		err = prov.Delete(t.Context(), p.DeleteRequest{
+			ID:  "id",
+			Urn: urn("Timeouts", "delete"),
+			Properties: property.NewMap(map[string]property.Value{
+				"name":         property.New("id"),
+				"stringPlus":   property.New("str+"),
+				"stringAndInt": property.New("str-1"),
+			}),
+			Timeout: engineTimeout,
+		})
+		assert.NoError(t, err)
+	}
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		test(t, 0, time.Hour)
+	})
+
+	t.Run("engine", func(t *testing.T) {
+		t.Parallel()
+		test(t, (10 * time.Minute).Seconds(), 10*time.Minute)
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type (
+	Volume     struct{}
+	VolumeArgs struct {
+		Size    int           `pulumi:"size"`
+		Mount   VolumeMount   `pulumi:"mount"`
+		Options *VolumeOption `pulumi:"options,optional"`
+	}
+	VolumeMount struct {
+		Path string `pulumi:"path"`
+	}
+	VolumeOption struct {
+		ReadOnly bool `pulumi:"readOnly"`
+	}
+)
+
+func (*Volume) Create(
+	_ context.Context, req infer.CreateRequest[VolumeArgs],
+) (infer.CreateResponse[VolumeArgs], error) {
+	return infer.CreateResponse[VolumeArgs]{ID: req.Name, Output: req.Inputs}, nil
+}
+
+// Descriptions set by Annotate take precedence over registered ones.
+func (o *VolumeOption) Annotate(a infer.Annotator) {
+	a.Describe(&o.ReadOnly, "Annotated.")
+}
+
+func init() {
+	infer.RegisterDescriptions[Volume](map[string]string{
+		"": "Volume is a block device.",
+	})
+	infer.RegisterDescriptions[VolumeArgs](map[string]string{
+		"size": "The size in GiB.",
+	})
+	infer.RegisterDescriptions[*VolumeMount](map[string]string{
+		"":     "VolumeMount is where a volume is mounted.",
+		"path": "The mount path.",
+	})
+	infer.RegisterDescriptions[VolumeOption](map[string]string{
+		"readOnly": "Registered.",
+	})
+}
+
+func TestRegisterDescriptions(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			Description     string          `json:"description"`
+			InputProperties json.RawMessage `json:"inputProperties"`
+		} `json:"resources"`
+		Types map[string]json.RawMessage `json:"types"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	volume := spec.Resources["test:index:Volume"]
+	assert.Equal(t, "Volume is a block device.", volume.Description)
+	assert.JSONEq(t, `{
+		"size": {"type": "integer", "description": "The size in GiB."},
+		"mount": {"$ref": "#/types/test:index:VolumeMount"},
+		"options": {"$ref": "#/types/test:index:VolumeOption"}
+	}`, string(volume.InputProperties))
+	assert.JSONEq(t, `{
+		"type": "object",
+		"description": "VolumeMount is where a volume is mounted.",
+		"properties": {"path": {"type": "string", "description": "The mount path."}},
+		"required": ["path"]
+	}`, string(spec.Types["test:index:VolumeMount"]))
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {"readOnly": {"type": "boolean", "description": "Annotated."}},
+		"required": ["readOnly"]
+	}`, string(spec.Types["test:index:VolumeOption"]))
+}
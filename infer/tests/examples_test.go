@@ -0,0 +1,120 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi-go-provider/integration"
+)
+
+type (
+	Queue     struct{}
+	QueueArgs struct {
+		Fifo bool `pulumi:"fifo,optional"`
+	}
+
+	BadQueue struct{}
+)
+
+func (q *Queue) Annotate(a infer.Annotator) {
+	a.Describe(&q, "A message queue.")
+	a.AddExample("A FIFO queue", map[string]string{
+		"yaml": `
+resources:
+  q:
+    type: test:index:Queue
+    properties:
+      fifo: true
+`,
+		"typescript": `new test.Queue("q", { fifo: true });`,
+	})
+}
+
+func (*Queue) Create(
+	_ context.Context, req infer.CreateRequest[QueueArgs],
+) (infer.CreateResponse[QueueArgs], error) {
+	return infer.CreateResponse[QueueArgs]{ID: req.Name, Output: req.Inputs}, nil
+}
+
+func (*BadQueue) Annotate(a infer.Annotator) {
+	a.AddExample("A misspelled queue", map[string]string{
+		"yaml": `
+resources:
+  q:
+    type: test:index:Queue
+    properties:
+      fiffo: true
+`,
+	})
+}
+
+func (*BadQueue) Create(
+	_ context.Context, req infer.CreateRequest[QueueArgs],
+) (infer.CreateResponse[QueueArgs], error) {
+	return infer.CreateResponse[QueueArgs]{ID: req.Name, Output: req.Inputs}, nil
+}
+
+func examplesProvider(t *testing.T, resources ...infer.InferredResource) integration.Server {
+	s, err := integration.NewServer(t.Context(), "test", semver.MustParse("1.0.0"),
+		integration.WithProvider(infer.Provider(infer.Options{
+			Resources: resources,
+			ModuleMap: map[tokens.ModuleName]tokens.ModuleName{"tests": "index"},
+		})))
+	require.NoError(t, err)
+	return s
+}
+
+func TestExamples(t *testing.T) {
+	t.Parallel()
+
+	resp, err := examplesProvider(t, infer.Resource(&Queue{})).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			Description string `json:"description"`
+		} `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	assert.Equal(t, "A message queue.\n\n"+
+		"{{% examples %}}\n"+
+		"## Example Usage\n"+
+		"{{% example %}}\n"+
+		"### A FIFO queue\n"+
+		"\n```typescript\nnew test.Queue(\"q\", { fifo: true });\n```\n"+
+		"\n```yaml\nresources:\n  q:\n    type: test:index:Queue\n    properties:\n      fifo: true\n```\n"+
+		"{{% /example %}}\n"+
+		"{{% /examples %}}", spec.Resources["test:index:Queue"].Description)
+}
+
+func TestExamplesValidation(t *testing.T) {
+	t.Parallel()
+
+	_, err := examplesProvider(t, infer.Resource(&Queue{}), infer.Resource(&BadQueue{})).
+		GetSchema(p.GetSchemaRequest{})
+	assert.ErrorContains(t, err, `invalid example for resource 'test:index:BadQueue': `+
+		`resource "q" sets 'fiffo', which is not an input of 'test:index:Queue'`)
+}
@@ -0,0 +1,140 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type (
+	Catalog     struct{}
+	CatalogArgs struct {
+		Featured Tagged[Product] `pulumi:"featured"`
+		Labels   Tagged[string]  `pulumi:"labels"`
+	}
+	CatalogState struct {
+		CatalogArgs
+		Products Paginated[Product]  `pulumi:"products"`
+		Tags     Paginated[[]string] `pulumi:"tags"`
+	}
+
+	Product struct {
+		Sku string `pulumi:"sku"`
+	}
+
+	Tagged[T any] struct {
+		Value T                 `pulumi:"value"`
+		Tags  map[string]string `pulumi:"tags,optional"`
+	}
+
+	Paginated[T any] struct {
+		Items []T     `pulumi:"items"`
+		Next  *string `pulumi:"next,optional"`
+	}
+)
+
+func (t *Tagged[T]) Annotate(a infer.Annotator) {
+	a.Describe(t, "A value with tags.")
+	a.SetGenericName("Tagged%s")
+}
+
+func (*Catalog) Create(
+	_ context.Context, req infer.CreateRequest[CatalogArgs],
+) (infer.CreateResponse[CatalogState], error) {
+	return infer.CreateResponse[CatalogState]{
+		ID: req.Name,
+		Output: CatalogState{
+			CatalogArgs: req.Inputs,
+			Products:    Paginated[Product]{Items: []Product{req.Inputs.Featured.Value}},
+			Tags:        Paginated[[]string]{Items: [][]string{{req.Inputs.Labels.Value}}},
+		},
+	}, nil
+}
+
+func TestGenericSchema(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			InputProperties json.RawMessage `json:"inputProperties"`
+			Properties      json.RawMessage `json:"properties"`
+		} `json:"resources"`
+		Types map[string]json.RawMessage `json:"types"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	catalog := spec.Resources["test:index:Catalog"]
+	assert.JSONEq(t, `{
+		"featured": {"$ref": "#/types/test:index:TaggedProduct"},
+		"labels": {"$ref": "#/types/test:index:TaggedString"}
+	}`, string(catalog.InputProperties))
+	assert.JSONEq(t, `{
+		"featured": {"$ref": "#/types/test:index:TaggedProduct"},
+		"labels": {"$ref": "#/types/test:index:TaggedString"},
+		"products": {"$ref": "#/types/test:index:PaginatedOfProduct"},
+		"tags": {"$ref": "#/types/test:index:PaginatedOfStringArray"}
+	}`, string(catalog.Properties))
+
+	assert.JSONEq(t, `{
+		"type": "object",
+		"description": "A value with tags.",
+		"properties": {
+			"value": {"$ref": "#/types/test:index:Product"},
+			"tags": {"type": "object", "additionalProperties": {"type": "string"}}
+		},
+		"required": ["value"]
+	}`, string(spec.Types["test:index:TaggedProduct"]))
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"items": {"type": "array", "items": {"type": "array", "items": {"type": "string"}}},
+			"next": {"type": "string"}
+		},
+		"required": ["items"]
+	}`, string(spec.Types["test:index:PaginatedOfStringArray"]))
+	assert.Contains(t, spec.Types, "test:index:PaginatedOfProduct")
+}
+
+func TestGeneric(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).Create(p.CreateRequest{
+		Urn: urn("Catalog", "c"),
+		Properties: property.NewMap(map[string]property.Value{
+			"featured": property.New(map[string]property.Value{
+				"value": property.New(map[string]property.Value{"sku": property.New("A-1")}),
+			}),
+			"labels": property.New(map[string]property.Value{"value": property.New("new")}),
+		}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, property.New(map[string]property.Value{
+		"items": property.New([]property.Value{
+			property.New(map[string]property.Value{"sku": property.New("A-1")}),
+		}),
+	}), resp.Properties.Get("products"))
+}
@@ -0,0 +1,163 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi-go-provider/infer/types"
+)
+
+type (
+	Policy     struct{}
+	PolicyArgs struct {
+		Document types.JSON      `pulumi:"document"`
+		Metadata json.RawMessage `pulumi:"metadata,optional"`
+	}
+	PolicyState struct {
+		PolicyArgs
+		Statements int `pulumi:"statements"`
+	}
+)
+
+func (*Policy) Create(
+	_ context.Context, req infer.CreateRequest[PolicyArgs],
+) (infer.CreateResponse[PolicyState], error) {
+	if req.DryRun {
+		return infer.CreateResponse[PolicyState]{ID: req.Name, Output: PolicyState{PolicyArgs: req.Inputs}}, nil
+	}
+	var doc struct {
+		Statement []any `json:"statement"`
+	}
+	if err := req.Inputs.Document.Unmarshal(&doc); err != nil {
+		return infer.CreateResponse[PolicyState]{}, err
+	}
+	return infer.CreateResponse[PolicyState]{
+		ID:     req.Name,
+		Output: PolicyState{PolicyArgs: req.Inputs, Statements: len(doc.Statement)},
+	}, nil
+}
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			InputProperties json.RawMessage `json:"inputProperties"`
+		} `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	assert.JSONEq(t, `{
+		"document": {"$ref": "pulumi.json#/Json"},
+		"metadata": {"$ref": "pulumi.json#/Json"}
+	}`, string(spec.Resources["test:index:Policy"].InputProperties))
+}
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	document := func(effect string) property.Value {
+		return property.New(map[string]property.Value{
+			"version": property.New("2012-10-17"),
+			"statement": property.New([]property.Value{
+				property.New(map[string]property.Value{
+					"effect":   property.New(effect),
+					"action":   property.New([]property.Value{property.New("s3:GetObject")}),
+					"resource": property.New("*"),
+				}),
+			}),
+		})
+	}
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn: urn("Policy", "p"),
+			Properties: property.NewMap(map[string]property.Value{
+				"document": document("Allow"),
+				"metadata": property.New(map[string]property.Value{
+					"owner": property.New("infra").WithSecret(true),
+					"count": property.New(2.0),
+				}),
+			}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, property.NewMap(map[string]property.Value{
+			"document": document("Allow"),
+			"metadata": property.New(map[string]property.Value{
+				"owner": property.New("infra").WithSecret(true),
+				"count": property.New(2.0),
+			}),
+			"statements": property.New(1.0),
+		}), resp.Properties)
+	})
+
+	t.Run("preview", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn: urn("Policy", "p"),
+			Properties: property.NewMap(map[string]property.Value{
+				"document": property.New(property.Computed),
+			}),
+			DryRun: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, property.New(property.Computed), resp.Properties.Get("document"))
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		t.Parallel()
+
+		state := property.NewMap(map[string]property.Value{
+			"document":   document("Allow"),
+			"statements": property.New(1.0),
+		})
+
+		resp, err := provider(t).Diff(p.DiffRequest{
+			ID:     "p",
+			Urn:    urn("Policy", "p"),
+			State:  state,
+			Inputs: property.NewMap(map[string]property.Value{"document": document("Allow")}),
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.HasChanges)
+
+		resp, err = provider(t).Diff(p.DiffRequest{
+			ID:     "p",
+			Urn:    urn("Policy", "p"),
+			State:  state,
+			Inputs: property.NewMap(map[string]property.Value{"document": document("Deny")}),
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.HasChanges)
+		assert.Equal(t, map[string]p.PropertyDiff{
+			"document.statement[0].effect": {Kind: p.UpdateReplace},
+		}, resp.DetailedDiff)
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type (
+	Trigger     struct{}
+	TriggerArgs struct {
+		Lambda string        `pulumi:"lambda"`
+		Filter TriggerFilter `pulumi:"filter"`
+	}
+	TriggerFilter struct {
+		Event string `pulumi:"event"`
+	}
+
+	ListTriggers     struct{}
+	ListTriggersArgs struct {
+		Lambda string `pulumi:"lambda"`
+	}
+)
+
+func (t *Trigger) Annotate(a infer.Annotator) {
+	a.SetLanguage(&t, "csharp", map[string]any{"name": "EventTrigger"})
+}
+
+func (args *TriggerArgs) Annotate(a infer.Annotator) {
+	a.SetLanguageName(&args.Lambda, "python", "lambda_")
+	a.SetLanguage(&args.Lambda, "csharp", map[string]any{"name": "LambdaArn"})
+}
+
+func (f *TriggerFilter) Annotate(a infer.Annotator) {
+	a.SetLanguage(&f, "csharp", map[string]any{"name": "EventFilter"})
+	a.SetLanguageName(&f.Event, "csharp", "EventName")
+}
+
+func (l *ListTriggers) Annotate(a infer.Annotator) {
+	a.SetLanguage(&l, "go", map[string]any{"name": "ListEventTriggers"})
+}
+
+func (*Trigger) Create(
+	_ context.Context, req infer.CreateRequest[TriggerArgs],
+) (infer.CreateResponse[TriggerArgs], error) {
+	return infer.CreateResponse[TriggerArgs]{ID: req.Name, Output: req.Inputs}, nil
+}
+
+func (*ListTriggers) Invoke(
+	_ context.Context, req infer.FunctionRequest[ListTriggersArgs],
+) (infer.FunctionResponse[ListTriggersArgs], error) {
+	return infer.FunctionResponse[ListTriggersArgs]{Output: req.Input}, nil
+}
+
+func TestLanguageSchema(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			Language        json.RawMessage `json:"language"`
+			InputProperties json.RawMessage `json:"inputProperties"`
+		} `json:"resources"`
+		Functions map[string]struct {
+			Language json.RawMessage `json:"language"`
+			Inputs   json.RawMessage `json:"inputs"`
+		} `json:"functions"`
+		Types map[string]json.RawMessage `json:"types"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	trigger := spec.Resources["test:index:Trigger"]
+	assert.JSONEq(t, `{"csharp": {"name": "EventTrigger"}}`, string(trigger.Language))
+	assert.JSONEq(t, `{
+		"lambda": {"type": "string", "language": {
+			"python": {"name": "lambda_"},
+			"csharp": {"name": "LambdaArn"}
+		}},
+		"filter": {"$ref": "#/types/test:index:TriggerFilter"}
+	}`, string(trigger.InputProperties))
+
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"event": {"type": "string", "language": {"csharp": {"name": "EventName"}}}
+		},
+		"required": ["event"],
+		"language": {"csharp": {"name": "EventFilter"}}
+	}`, string(spec.Types["test:index:TriggerFilter"]))
+
+	list := spec.Functions["test:index:listTriggers"]
+	assert.JSONEq(t, `{"go": {"name": "ListEventTriggers"}}`, string(list.Language))
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"lambda": {"type": "string"}
+		},
+		"required": ["lambda"]
+	}`, string(list.Inputs))
+}
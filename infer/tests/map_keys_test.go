@@ -0,0 +1,142 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/netip"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type (
+	Fleet     struct{}
+	FleetArgs struct {
+		Regions map[CloudRegion]FleetConfig `pulumi:"regions"`
+		Weights map[uint8]float64           `pulumi:"weights,optional"`
+		Hosts   map[netip.Addr]string       `pulumi:"hosts,optional"`
+	}
+	FleetConfig struct {
+		Size int `pulumi:"size"`
+	}
+
+	CloudRegion string
+)
+
+func (CloudRegion) Values() []infer.EnumValue[CloudRegion] {
+	return []infer.EnumValue[CloudRegion]{
+		{Name: "UsEast1", Value: "us-east-1"},
+		{Name: "EuWest1", Value: "eu-west-1"},
+	}
+}
+
+func (*Fleet) Create(
+	_ context.Context, req infer.CreateRequest[FleetArgs],
+) (infer.CreateResponse[FleetArgs], error) {
+	return infer.CreateResponse[FleetArgs]{ID: req.Name, Output: req.Inputs}, nil
+}
+
+func TestMapKeysSchema(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			InputProperties json.RawMessage `json:"inputProperties"`
+		} `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	assert.JSONEq(t, `{
+		"regions": {
+			"type": "object",
+			"additionalProperties": {"$ref": "#/types/test:index:FleetConfig"}
+		},
+		"weights": {
+			"type": "object",
+			"additionalProperties": {"type": "number"}
+		},
+		"hosts": {
+			"type": "object",
+			"additionalProperties": {"type": "string"}
+		}
+	}`, string(spec.Resources["test:index:Fleet"].InputProperties))
+}
+
+func TestMapKeys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("check", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Check(p.CheckRequest{
+			Urn: urn("Fleet", "f"),
+			Inputs: property.NewMap(map[string]property.Value{
+				"regions": property.New(map[string]property.Value{
+					"us-west-9": property.New(map[string]property.Value{
+						"size": property.New(1.0),
+					}),
+				}),
+				"weights": property.New(map[string]property.Value{
+					"300": property.New(0.5),
+				}),
+			}),
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []p.CheckFailure{
+			{
+				Property: "regions",
+				Reason:   `invalid key "us-west-9": must be one of "us-east-1", "eu-west-1"`,
+			},
+			{
+				Property: "weights",
+				Reason:   `invalid key "300": expected an integer between 0 and 255`,
+			},
+		}, resp.Failures)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		props := property.NewMap(map[string]property.Value{
+			"regions": property.New(map[string]property.Value{
+				"eu-west-1": property.New(map[string]property.Value{
+					"size": property.New(3.0),
+				}),
+			}),
+			"weights": property.New(map[string]property.Value{
+				"7": property.New(0.5),
+			}),
+			"hosts": property.New(map[string]property.Value{
+				"192.168.0.1": property.New("gateway"),
+			}),
+		})
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn:        urn("Fleet", "f"),
+			Properties: props,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, props, resp.Properties)
+	})
+}
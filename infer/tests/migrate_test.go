@@ -282,3 +282,351 @@ func (*MigrateR) Diff(_ context.Context,
 type viaError[T any] struct{ t T }
 
 func (viaError[T]) Error() string { panic("NOT FOR DISPLAY") }
+
+var (
+	_ infer.CustomInputMigrations[MigrateInputsArgs]           = (*MigrateInputsR)(nil)
+	_ infer.CustomCheck[MigrateInputsArgs]                     = (*MigrateInputsR)(nil)
+	_ infer.CustomUpdate[MigrateInputsArgs, MigrateInputsArgs] = (*MigrateInputsR)(nil)
+)
+
+// MigrateInputsR renamed its "size" input to "sizeGb".
+type MigrateInputsR struct{}
+
+type MigrateInputsArgsV1 struct {
+	Size     int  `pulumi:"size"`
+	Replicas *int `pulumi:"replicas,optional"`
+}
+
+type MigrateInputsArgs struct {
+	SizeGb   int  `pulumi:"sizeGb"`
+	Replicas *int `pulumi:"replicas,optional"`
+}
+
+func (*MigrateInputsR) InputMigrations(context.Context) []infer.StateMigrationFunc[MigrateInputsArgs] {
+	return []infer.StateMigrationFunc[MigrateInputsArgs]{
+		infer.StateMigration(func(
+			_ context.Context, v1 MigrateInputsArgsV1,
+		) (infer.MigrationResult[MigrateInputsArgs], error) {
+			return infer.MigrationResult[MigrateInputsArgs]{
+				Result: &MigrateInputsArgs{SizeGb: v1.Size, Replicas: v1.Replicas},
+			}, nil
+		}),
+	}
+}
+
+// Check fails if the old inputs were not migrated, so that tests can observe them.
+func (*MigrateInputsR) Check(
+	ctx context.Context, req infer.CheckRequest,
+) (infer.CheckResponse[MigrateInputsArgs], error) {
+	var failures []p.CheckFailure
+	if req.OldInputs.Len() > 0 && !req.OldInputs.Get("sizeGb").IsNumber() {
+		failures = append(failures, p.CheckFailure{Property: "sizeGb", Reason: "old inputs were not migrated"})
+	}
+	args, f, err := infer.DefaultCheck[MigrateInputsArgs](ctx, req.NewInputs)
+	return infer.CheckResponse[MigrateInputsArgs]{Inputs: args, Failures: append(failures, f...)}, err
+}
+
+func (*MigrateInputsR) Create(
+	_ context.Context, req infer.CreateRequest[MigrateInputsArgs],
+) (infer.CreateResponse[MigrateInputsArgs], error) {
+	return infer.CreateResponse[MigrateInputsArgs]{ID: "id", Output: req.Inputs}, nil
+}
+
+func (*MigrateInputsR) Update(
+	_ context.Context, req infer.UpdateRequest[MigrateInputsArgs, MigrateInputsArgs],
+) (infer.UpdateResponse[MigrateInputsArgs], error) {
+	return infer.UpdateResponse[MigrateInputsArgs]{Output: req.Inputs}, nil
+}
+
+func TestMigrateInputs(t *testing.T) {
+	t.Parallel()
+
+	oldInputs := property.NewMap(map[string]property.Value{"size": property.New(10.0)})
+
+	t.Run("check", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Check(p.CheckRequest{
+			Urn:    urn("MigrateInputsR", "check"),
+			State:  oldInputs,
+			Inputs: property.NewMap(map[string]property.Value{"sizeGb": property.New(10.0)}),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Failures)
+	})
+
+	t.Run("diff-renamed", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Diff(p.DiffRequest{
+			ID:     "id",
+			Urn:    urn("MigrateInputsR", "diff"),
+			State:  oldInputs,
+			Inputs: property.NewMap(map[string]property.Value{"sizeGb": property.New(10.0)}),
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.HasChanges)
+		assert.Empty(t, resp.DetailedDiff)
+	})
+
+	t.Run("diff-secret", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Diff(p.DiffRequest{
+			ID:  "id",
+			Urn: urn("MigrateInputsR", "diff"),
+			State: property.NewMap(map[string]property.Value{
+				"size":     property.New(10.0),
+				"replicas": property.New(3.0).WithSecret(true),
+			}),
+			Inputs: property.NewMap(map[string]property.Value{
+				"sizeGb":   property.New(10.0),
+				"replicas": property.New(3.0).WithSecret(true),
+			}),
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.HasChanges)
+		assert.Empty(t, resp.DetailedDiff)
+	})
+
+	t.Run("diff-shared-value", func(t *testing.T) {
+		t.Parallel()
+
+		// sizeGb is not secret, even though it has the same value as a secret.
+		resp, err := provider(t).Diff(p.DiffRequest{
+			ID:  "id",
+			Urn: urn("MigrateInputsR", "diff"),
+			State: property.NewMap(map[string]property.Value{
+				"size":     property.New(10.0),
+				"replicas": property.New(10.0).WithSecret(true),
+			}),
+			Inputs: property.NewMap(map[string]property.Value{
+				"sizeGb":   property.New(10.0),
+				"replicas": property.New(10.0).WithSecret(true),
+			}),
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.HasChanges)
+		assert.Empty(t, resp.DetailedDiff)
+	})
+
+	t.Run("diff-changed", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Diff(p.DiffRequest{
+			ID:     "id",
+			Urn:    urn("MigrateInputsR", "diff"),
+			State:  oldInputs,
+			Inputs: property.NewMap(map[string]property.Value{"sizeGb": property.New(20.0)}),
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.HasChanges)
+		assert.Equal(t, map[string]p.PropertyDiff{
+			"sizeGb": {Kind: p.Update, InputDiff: false},
+		}, resp.DetailedDiff)
+	})
+
+	t.Run("diff-current", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Diff(p.DiffRequest{
+			ID:     "id",
+			Urn:    urn("MigrateInputsR", "diff"),
+			State:  property.NewMap(map[string]property.Value{"sizeGb": property.New(10.0)}),
+			Inputs: property.NewMap(map[string]property.Value{"sizeGb": property.New(10.0)}),
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.HasChanges)
+	})
+}
+
+// MigrateAutoNamedR renamed its auto-named "title" input to "name".
+type MigrateAutoNamedR struct{}
+
+type MigrateAutoNamedArgsV1 struct {
+	Title string `pulumi:"title"`
+}
+
+type MigrateAutoNamedArgs struct {
+	Name *string `pulumi:"name,optional"`
+}
+
+func (a *MigrateAutoNamedArgs) Annotate(an infer.Annotator) {
+	an.SetAutoName(&a.Name, "${name}-${hex(4)}", 0)
+}
+
+func (*MigrateAutoNamedR) InputMigrations(context.Context) []infer.StateMigrationFunc[MigrateAutoNamedArgs] {
+	return []infer.StateMigrationFunc[MigrateAutoNamedArgs]{
+		infer.StateMigration(func(
+			_ context.Context, v1 MigrateAutoNamedArgsV1,
+		) (infer.MigrationResult[MigrateAutoNamedArgs], error) {
+			return infer.MigrationResult[MigrateAutoNamedArgs]{
+				Result: &MigrateAutoNamedArgs{Name: &v1.Title},
+			}, nil
+		}),
+	}
+}
+
+func (*MigrateAutoNamedR) Create(
+	_ context.Context, req infer.CreateRequest[MigrateAutoNamedArgs],
+) (infer.CreateResponse[MigrateAutoNamedArgs], error) {
+	return infer.CreateResponse[MigrateAutoNamedArgs]{ID: "id", Output: req.Inputs}, nil
+}
+
+func TestMigrateInputsAutoName(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).Check(p.CheckRequest{
+		Urn:    urn("MigrateAutoNamedR", "check"),
+		State:  property.NewMap(map[string]property.Value{"title": property.New("check-1a2b")}),
+		Inputs: property.Map{},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Failures)
+	assert.Equal(t, property.NewMap(map[string]property.Value{
+		"name": property.New("check-1a2b"),
+	}), resp.Inputs)
+}
+
+var (
+	_ infer.CustomStateVersions                           = (*VersionedR)(nil)
+	_ infer.CustomUpdate[VersionedArgs, VersionedStateV2] = (*VersionedR)(nil)
+	_ infer.CustomRead[VersionedArgs, VersionedStateV2]   = (*VersionedR)(nil)
+)
+
+// VersionedR has had three shapes of state, each recorded as a version.
+type VersionedR struct{}
+
+type VersionedArgs struct {
+	SizeGb int `pulumi:"sizeGb"`
+}
+
+type VersionedStateV0 struct {
+	SizeMb int `pulumi:"sizeMb"`
+}
+
+type VersionedStateV1 struct {
+	Size   int     `pulumi:"size"`
+	Secret *string `pulumi:"secret,optional"`
+}
+
+type VersionedStateV2 struct {
+	SizeGb int     `pulumi:"sizeGb"`
+	Secret *string `pulumi:"secret,optional"`
+}
+
+func (*VersionedR) StateVersions(context.Context) []infer.StateVersionMigration {
+	return []infer.StateVersionMigration{
+		infer.StateVersion(1, func(_ context.Context, v0 VersionedStateV0) (VersionedStateV1, error) {
+			return VersionedStateV1{Size: v0.SizeMb / 1024}, nil
+		}),
+		infer.StateVersion(2, func(_ context.Context, v1 VersionedStateV1) (VersionedStateV2, error) {
+			return VersionedStateV2{SizeGb: v1.Size, Secret: v1.Secret}, nil
+		}),
+	}
+}
+
+func (*VersionedR) Create(
+	_ context.Context, req infer.CreateRequest[VersionedArgs],
+) (infer.CreateResponse[VersionedStateV2], error) {
+	return infer.CreateResponse[VersionedStateV2]{
+		ID:     "id",
+		Output: VersionedStateV2{SizeGb: req.Inputs.SizeGb},
+	}, nil
+}
+
+// Just return the old state so it is visible to tests.
+func (*VersionedR) Update(
+	_ context.Context, req infer.UpdateRequest[VersionedArgs, VersionedStateV2],
+) (infer.UpdateResponse[VersionedStateV2], error) {
+	return infer.UpdateResponse[VersionedStateV2]{Output: req.State}, nil
+}
+
+func (*VersionedR) Read(
+	_ context.Context, req infer.ReadRequest[VersionedArgs, VersionedStateV2],
+) (infer.ReadResponse[VersionedArgs, VersionedStateV2], error) {
+	return infer.ReadResponse[VersionedArgs, VersionedStateV2](req), nil
+}
+
+func TestStateVersions(t *testing.T) {
+	t.Parallel()
+
+	versioned := func(version float64, props map[string]property.Value) property.Map {
+		props["__internal"] = property.New(map[string]property.Value{
+			"pulumi-go-provider-state-version": property.New(version),
+		})
+		return property.NewMap(props)
+	}
+	update := func(t *testing.T, state property.Map) (property.Map, error) {
+		resp, err := provider(t).Update(p.UpdateRequest{
+			ID:     "id",
+			Urn:    urn("VersionedR", "update"),
+			State:  state,
+			Inputs: property.NewMap(map[string]property.Value{"sizeGb": property.New(2.0)}),
+		})
+		return resp.Properties, err
+	}
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn:        urn("VersionedR", "create"),
+			Properties: property.NewMap(map[string]property.Value{"sizeGb": property.New(2.0)}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, versioned(2, map[string]property.Value{
+			"sizeGb": property.New(2.0),
+		}), resp.Properties)
+	})
+
+	t.Run("unversioned", func(t *testing.T) {
+		t.Parallel()
+
+		props, err := update(t, property.NewMap(map[string]property.Value{
+			"sizeMb": property.New(2048.0),
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, versioned(2, map[string]property.Value{
+			"sizeGb": property.New(2.0),
+		}), props)
+	})
+
+	t.Run("v1", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Read(p.ReadRequest{
+			ID:  "id",
+			Urn: urn("VersionedR", "read"),
+			Properties: versioned(1, map[string]property.Value{
+				"size":   property.New(2.0),
+				"secret": property.New("s").WithSecret(true),
+			}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, versioned(2, map[string]property.Value{
+			"sizeGb": property.New(2.0),
+			"secret": property.New("s").WithSecret(true),
+		}), resp.Properties)
+	})
+
+	t.Run("current", func(t *testing.T) {
+		t.Parallel()
+
+		state := versioned(2, map[string]property.Value{
+			"sizeGb": property.New(2.0),
+		})
+		props, err := update(t, state)
+		require.NoError(t, err)
+		assert.Equal(t, state, props)
+	})
+
+	t.Run("newer", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := update(t, versioned(3, map[string]property.Value{
+			"sizeGb": property.New(2.0),
+		}))
+		assert.ErrorContains(t, err, "state has version 3, but only versions up to 2 are known")
+	})
+}
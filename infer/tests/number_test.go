@@ -0,0 +1,160 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type (
+	Listener     struct{}
+	ListenerArgs struct {
+		Port     uint16   `pulumi:"port,optional"`
+		Ratio    float32  `pulumi:"ratio"`
+		Backlog  *int32   `pulumi:"backlog,optional"`
+		Priority Priority `pulumi:"priority"`
+		Count    int      `pulumi:"count"`
+	}
+
+	Priority uint8
+)
+
+const (
+	PriorityLow  Priority = 1
+	PriorityHigh Priority = 9
+)
+
+func (Priority) Values() []infer.EnumValue[Priority] {
+	return []infer.EnumValue[Priority]{
+		{Name: "Low", Value: PriorityLow},
+		{Name: "High", Value: PriorityHigh},
+	}
+}
+
+func (a *ListenerArgs) Annotate(an infer.Annotator) {
+	an.Describe(&a.Port, "The port to listen on.")
+	an.SetDefault(&a.Port, 8080)
+}
+
+func (*Listener) Create(
+	_ context.Context, req infer.CreateRequest[ListenerArgs],
+) (infer.CreateResponse[ListenerArgs], error) {
+	return infer.CreateResponse[ListenerArgs]{ID: req.Name, Output: req.Inputs}, nil
+}
+
+func TestNumberSchema(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			InputProperties json.RawMessage `json:"inputProperties"`
+		} `json:"resources"`
+		Types map[string]json.RawMessage `json:"types"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	assert.JSONEq(t, `{
+		"port": {
+			"type": "integer",
+			"default": 8080,
+			"description": "The port to listen on.\n\nMust be between 0 and 65535."
+		},
+		"ratio": {
+			"type": "number",
+			"description": "Must be between -3.4028234663852886e+38 and 3.4028234663852886e+38."
+		},
+		"backlog": {
+			"type": "integer",
+			"description": "Must be between -2147483648 and 2147483647."
+		},
+		"priority": {"$ref": "#/types/test:index:Priority"},
+		"count": {"type": "integer"}
+	}`, string(spec.Resources["test:index:Listener"].InputProperties))
+	assert.JSONEq(t, `{
+		"type": "integer",
+		"enum": [
+			{"value": 1},
+			{"value": 9}
+		]
+	}`, string(spec.Types["test:index:Priority"]))
+}
+
+func TestNumbers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("check", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Check(p.CheckRequest{
+			Urn: urn("Listener", "l"),
+			Inputs: property.NewMap(map[string]property.Value{
+				"port":     property.New(70000.0),
+				"ratio":    property.New(0.5),
+				"backlog":  property.New(3e10),
+				"priority": property.New(1.0),
+				"count":    property.New(2.5),
+			}),
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []p.CheckFailure{
+			{Property: "port", Reason: "70000 is out of range for uint16: must be between 0 and 65535"},
+			{
+				Property: "backlog",
+				Reason:   "3e+10 is out of range for int32: must be between -2147483648 and 2147483647",
+			},
+			{Property: "count", Reason: "2.5 is not an integer"},
+		}, resp.Failures)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		s := provider(t)
+		check, err := s.Check(p.CheckRequest{
+			Urn: urn("Listener", "l"),
+			Inputs: property.NewMap(map[string]property.Value{
+				"ratio":    property.New(0.5),
+				"priority": property.New(9.0),
+				"count":    property.New(3.0),
+			}),
+		})
+		require.NoError(t, err)
+		require.Empty(t, check.Failures)
+
+		resp, err := s.Create(p.CreateRequest{
+			Urn:        urn("Listener", "l"),
+			Properties: check.Inputs,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, property.NewMap(map[string]property.Value{
+			"port":     property.New(8080.0),
+			"ratio":    property.New(0.5),
+			"priority": property.New(9.0),
+			"count":    property.New(3.0),
+		}), resp.Properties)
+	})
+}
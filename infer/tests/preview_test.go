@@ -0,0 +1,176 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type (
+	Previewed     struct{}
+	PreviewedArgs struct {
+		Name     string `pulumi:"name"`
+		Password string `pulumi:"password,optional" provider:"secret"`
+	}
+	PreviewedState struct {
+		PreviewedArgs
+		Arn       string `pulumi:"arn"`
+		CreatedAt string `pulumi:"createdAt"`
+		Public    bool   `pulumi:"public"`
+		Revision  int    `pulumi:"revision"`
+	}
+)
+
+var errSideEffect = errors.New("Create and Update must not be called during preview")
+
+func (*Previewed) Preview(
+	_ context.Context, req infer.PreviewRequest[PreviewedArgs, PreviewedState],
+) (infer.PreviewResponse[PreviewedState], error) {
+	state := PreviewedState{PreviewedArgs: req.Inputs}
+	if !req.IsUnknown(&req.Inputs.Name) {
+		state.Arn = "arn:" + req.Inputs.Name
+	}
+	resp := infer.PreviewResponse[PreviewedState]{Output: state}
+	resp.SetKnown(&resp.Output.Public)
+	return resp, nil
+}
+
+func (*Previewed) WireDependencies(f infer.FieldSelector, _ *PreviewedArgs, state *PreviewedState) {
+	f.OutputField(&state.Revision).AlwaysKnown()
+}
+
+func (*Previewed) Create(
+	_ context.Context, req infer.CreateRequest[PreviewedArgs],
+) (infer.CreateResponse[PreviewedState], error) {
+	if req.DryRun {
+		return infer.CreateResponse[PreviewedState]{}, errSideEffect
+	}
+	return infer.CreateResponse[PreviewedState]{
+		ID: req.Inputs.Name,
+		Output: PreviewedState{
+			PreviewedArgs: req.Inputs,
+			Arn:           "arn:" + req.Inputs.Name,
+			CreatedAt:     "now",
+		},
+	}, nil
+}
+
+func (*Previewed) Update(
+	_ context.Context, req infer.UpdateRequest[PreviewedArgs, PreviewedState],
+) (infer.UpdateResponse[PreviewedState], error) {
+	if req.DryRun {
+		return infer.UpdateResponse[PreviewedState]{}, errSideEffect
+	}
+	return infer.UpdateResponse[PreviewedState]{Output: req.State}, nil
+}
+
+func TestCustomPreview(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn: urn("Previewed", "preview"),
+			Properties: property.NewMap(map[string]property.Value{
+				"name":     property.New("b"),
+				"password": property.New("hunter2").WithSecret(true),
+			}),
+			DryRun: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, property.NewMap(map[string]property.Value{
+			"name":      property.New("b"),
+			"password":  property.New("hunter2").WithSecret(true),
+			"arn":       property.New("arn:b"),
+			"createdAt": property.New(property.Computed),
+			"public":    property.New(false),
+			"revision":  property.New(0.0),
+		}), resp.Properties)
+	})
+
+	t.Run("create-unknown-input", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn: urn("Previewed", "preview"),
+			Properties: property.NewMap(map[string]property.Value{
+				"name": property.New(property.Computed),
+			}),
+			DryRun: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, property.NewMap(map[string]property.Value{
+			"name":      property.New(property.Computed),
+			"password":  property.New(property.Computed),
+			"arn":       property.New(property.Computed),
+			"createdAt": property.New(property.Computed),
+			"public":    property.New(false),
+			"revision":  property.New(0.0),
+		}), resp.Properties)
+	})
+
+	t.Run("create-not-preview", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn: urn("Previewed", "preview"),
+			Properties: property.NewMap(map[string]property.Value{
+				"name": property.New("b"),
+			}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "b", resp.ID)
+		assert.Equal(t, property.New("now"), resp.Properties.Get("createdAt"))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Update(p.UpdateRequest{
+			ID:  "b",
+			Urn: urn("Previewed", "preview"),
+			State: property.NewMap(map[string]property.Value{
+				"name":      property.New("b"),
+				"arn":       property.New("arn:b"),
+				"createdAt": property.New("then"),
+				"public":    property.New(true),
+				"revision":  property.New(1.0),
+			}),
+			Inputs: property.NewMap(map[string]property.Value{
+				"name": property.New("c"),
+			}),
+			DryRun: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, property.NewMap(map[string]property.Value{
+			"name":      property.New("c"),
+			"password":  property.New(property.Computed),
+			"arn":       property.New("arn:c"),
+			"createdAt": property.New(property.Computed),
+			"public":    property.New(false),
+			"revision":  property.New(0.0),
+		}), resp.Properties)
+	})
+}
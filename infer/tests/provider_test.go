@@ -597,6 +597,25 @@ func providerOpts(config infer.InferredConfig) infer.Options {
 			infer.Resource(&ReadConfig{}),
 			infer.Resource(&ReadConfigCustom{}),
 			infer.Resource(&CustomCheckNoDefaults{}),
+			infer.Resource(&Renamed{}),
+			infer.Resource(&VersionedR{}),
+			infer.Resource(&AutoNamed{}),
+			infer.ReadOnlyResource(&Region{}),
+			infer.Resource(&Unreadable{}),
+			infer.Resource(&Previewed{}),
+			infer.Resource(&Deployment{}),
+			infer.Resource(&Schedule{}),
+			infer.Resource(&Listener{}),
+			infer.Resource(&Fleet{}),
+			infer.Resource(&Policy{}),
+			infer.Resource(&Network{}),
+			infer.Resource(&Subnet{}),
+			infer.Resource(&Catalog{}),
+			infer.Resource(&Volume{}),
+			infer.Resource(&Trigger{}),
+			infer.Resource(&MigrateInputsR{}),
+			infer.Resource(&MigrateAutoNamedR{}),
+			infer.Resource(&Wrapped{}),
 		},
 		Components: []infer.InferredComponent{
 			infer.ComponentF(NewRandomComponent),
@@ -604,6 +623,7 @@ func providerOpts(config infer.InferredConfig) infer.Options {
 		},
 		Functions: []infer.InferredFunction{
 			infer.Function(&GetJoin{}),
+			infer.Function(&ListTriggers{}),
 		},
 		ModuleMap: map[tokens.ModuleName]tokens.ModuleName{"tests": "index"},
 	}
@@ -0,0 +1,116 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type (
+	Region      struct{}
+	RegionArgs  struct{}
+	RegionState struct {
+		Name     string `pulumi:"name"`
+		Endpoint string `pulumi:"endpoint"`
+	}
+
+	Unreadable     struct{}
+	UnreadableArgs struct {
+		Value string `pulumi:"value"`
+	}
+)
+
+func (*Region) Read(
+	_ context.Context, req infer.ReadRequest[RegionArgs, RegionState],
+) (infer.ReadResponse[RegionArgs, RegionState], error) {
+	return infer.ReadResponse[RegionArgs, RegionState]{
+		ID: req.ID,
+		State: RegionState{
+			Name:     req.ID,
+			Endpoint: "https://" + req.ID + ".example.com",
+		},
+	}, nil
+}
+
+func (*Unreadable) Create(
+	_ context.Context, req infer.CreateRequest[UnreadableArgs],
+) (infer.CreateResponse[UnreadableArgs], error) {
+	return infer.CreateResponse[UnreadableArgs]{ID: req.Name, Output: req.Inputs}, nil
+}
+
+func TestReadStateInputsSchema(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			StateInputs json.RawMessage `json:"stateInputs"`
+		} `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	assert.JSONEq(t, `{
+		"type": "object",
+		"description": "Input properties used for looking up and filtering resources.",
+		"properties": {
+			"endpoint": {"type": "string"},
+			"name": {"type": "string"}
+		}
+	}`, string(spec.Resources["test:index:Region"].StateInputs))
+	assert.Nil(t, spec.Resources["test:index:Unreadable"].StateInputs)
+}
+
+func TestReadOnlyResource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("read", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Read(p.ReadRequest{
+			ID:  "us-west-2",
+			Urn: urn("Region", "west"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "us-west-2", resp.ID)
+		assert.Equal(t, property.NewMap(map[string]property.Value{
+			"name":     property.New("us-west-2"),
+			"endpoint": property.New("https://us-west-2.example.com"),
+		}), resp.Properties)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := provider(t).Create(p.CreateRequest{
+			Urn: urn("Region", "west"),
+		})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+		assert.ErrorContains(t, err,
+			"test:index:Region is read-only: it cannot be created, only read with its get function")
+	})
+}
@@ -0,0 +1,192 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type (
+	Network     struct{}
+	NetworkArgs struct {
+		Cidr string `pulumi:"cidr"`
+	}
+	NetworkState struct {
+		NetworkArgs
+		Gateway string `pulumi:"gateway"`
+	}
+
+	Subnet     struct{}
+	SubnetArgs struct {
+		Network infer.ResourceRef[*Network] `pulumi:"network"`
+	}
+	SubnetState struct {
+		SubnetArgs
+		NetworkID string  `pulumi:"networkId"`
+		Gateway   *string `pulumi:"gateway,optional"`
+	}
+)
+
+func (*Network) Create(
+	_ context.Context, req infer.CreateRequest[NetworkArgs],
+) (infer.CreateResponse[NetworkState], error) {
+	return infer.CreateResponse[NetworkState]{
+		ID:     "net-" + req.Name,
+		Output: NetworkState{NetworkArgs: req.Inputs, Gateway: "10.0.0.1"},
+	}, nil
+}
+
+func (*Subnet) Create(
+	ctx context.Context, req infer.CreateRequest[SubnetArgs],
+) (infer.CreateResponse[SubnetState], error) {
+	state := SubnetState{SubnetArgs: req.Inputs, NetworkID: string(req.Inputs.Network.ID)}
+	if network, ok := infer.RefState[NetworkState](ctx, req.Inputs.Network); ok {
+		state.Gateway = &network.Gateway
+	}
+	return infer.CreateResponse[SubnetState]{ID: req.Name, Output: state}, nil
+}
+
+func TestResourceRefSchema(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			InputProperties json.RawMessage `json:"inputProperties"`
+		} `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	assert.JSONEq(t, `{
+		"network": {"$ref": "#/resources/test:index:Network"}
+	}`, string(spec.Resources["test:index:Subnet"].InputProperties))
+}
+
+func TestResourceRef(t *testing.T) {
+	t.Parallel()
+
+	networkRef := func(id resource.ID) property.Value {
+		return resource.FromResourcePropertyValue(
+			resource.MakeCustomResourceReference(urn("Network", "main"), id, "1.0.0"))
+	}
+
+	t.Run("check", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Check(p.CheckRequest{
+			Urn: urn("Subnet", "s"),
+			Inputs: property.NewMap(map[string]property.Value{
+				"network": property.New("net-main"),
+			}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []p.CheckFailure{
+			{Property: "network", Reason: "expected a resource reference"},
+		}, resp.Failures)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		s := provider(t)
+		_, err := s.Create(p.CreateRequest{
+			Urn:        urn("Network", "main"),
+			Properties: property.NewMap(map[string]property.Value{"cidr": property.New("10.0.0.0/16")}),
+		})
+		require.NoError(t, err)
+
+		resp, err := s.Create(p.CreateRequest{
+			Urn:        urn("Subnet", "s"),
+			Properties: property.NewMap(map[string]property.Value{"network": networkRef("net-main")}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, property.NewMap(map[string]property.Value{
+			"network":   networkRef("net-main"),
+			"networkId": property.New("net-main"),
+			"gateway":   property.New("10.0.0.1"),
+		}), resp.Properties)
+	})
+
+	t.Run("unknown state", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn:        urn("Subnet", "s"),
+			Properties: property.NewMap(map[string]property.Value{"network": networkRef("net-main")}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, property.NewMap(map[string]property.Value{
+			"network":   networkRef("net-main"),
+			"networkId": property.New("net-main"),
+		}), resp.Properties)
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		t.Parallel()
+
+		// Diff is sent the state from before the update, so it is not recorded.
+		s := provider(t)
+		_, err := s.Diff(p.DiffRequest{
+			ID:  "net-main",
+			Urn: urn("Network", "main"),
+			State: property.NewMap(map[string]property.Value{
+				"cidr":    property.New("10.0.0.0/16"),
+				"gateway": property.New("10.0.0.1"),
+			}),
+			Inputs: property.NewMap(map[string]property.Value{"cidr": property.New("10.1.0.0/16")}),
+		})
+		require.NoError(t, err)
+
+		resp, err := s.Create(p.CreateRequest{
+			Urn:        urn("Subnet", "s"),
+			Properties: property.NewMap(map[string]property.Value{"network": networkRef("net-main")}),
+		})
+		require.NoError(t, err)
+		_, hasGateway := resp.Properties.GetOk("gateway")
+		assert.False(t, hasGateway)
+	})
+
+	t.Run("wrong state type", func(t *testing.T) {
+		t.Parallel()
+
+		assert.PanicsWithValue(t,
+			"RefState[tests.SubnetState] called on a reference to a resource with outputs of type tests.NetworkState",
+			func() { infer.RefState[SubnetState](t.Context(), infer.ResourceRef[*Network]{}) })
+	})
+
+	t.Run("preview", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn:        urn("Subnet", "s"),
+			Properties: property.NewMap(map[string]property.Value{"network": networkRef("")}),
+			DryRun:     true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, networkRef(""), resp.Properties.Get("network"))
+	})
+}
@@ -79,7 +79,82 @@ func TestGetSchema(t *testing.T) {
         "value": { "type": "string", "default": "default-value" }
       },
       "type": "object"
-    }
+    },
+    "test:index:BucketSource": {
+      "properties": { "bucket": { "type": "string" } },
+      "type": "object",
+      "required": ["bucket"]
+    },
+    "test:index:CronTrigger": {
+      "properties": { "kind": { "type": "string" }, "schedule": { "type": "string" } },
+      "type": "object",
+      "required": ["kind", "schedule"]
+    },
+    "test:index:PushTrigger": {
+      "properties": { "branch": { "type": "string" }, "kind": { "type": "string" } },
+      "type": "object",
+      "required": ["kind", "branch"]
+    },
+    "test:index:RepoSource": {
+      "properties": { "ref": { "type": "string" }, "repo": { "type": "string" } },
+      "type": "object",
+      "required": ["repo"]
+    },
+    "test:index:Priority": { "type": "integer", "enum": [{ "value": 1 }, { "value": 9 }] },
+    "test:index:FleetConfig": { "properties": { "size": { "type": "integer" } }, "type": "object", "required": ["size"] },
+    "test:index:PaginatedOfProduct": {
+      "properties": {
+        "items": { "type": "array", "items": { "$ref": "#/types/test:index:Product" } },
+        "next": { "type": "string" }
+      },
+      "type": "object",
+      "required": ["items"]
+    },
+    "test:index:PaginatedOfStringArray": {
+      "properties": {
+        "items": { "type": "array", "items": { "type": "array", "items": { "type": "string" } } },
+        "next": { "type": "string" }
+      },
+      "type": "object",
+      "required": ["items"]
+    },
+    "test:index:Product": { "properties": { "sku": { "type": "string" } }, "type": "object", "required": ["sku"] },
+    "test:index:TaggedProduct": {
+      "description": "A value with tags.",
+      "properties": {
+        "tags": { "type": "object", "additionalProperties": { "type": "string" } },
+        "value": { "$ref": "#/types/test:index:Product" }
+      },
+      "type": "object",
+      "required": ["value"]
+    },
+    "test:index:TaggedString": {
+      "description": "A value with tags.",
+      "properties": {
+        "tags": { "type": "object", "additionalProperties": { "type": "string" } },
+        "value": { "type": "string" }
+      },
+      "type": "object",
+      "required": ["value"]
+    },
+    "test:index:VolumeMount": {
+      "description": "VolumeMount is where a volume is mounted.",
+      "properties": { "path": { "type": "string", "description": "The mount path." } },
+      "type": "object",
+      "required": ["path"]
+    },
+    "test:index:VolumeOption": {
+      "properties": { "readOnly": { "type": "boolean", "description": "Annotated." } },
+      "type": "object",
+      "required": ["readOnly"]
+    },
+    "test:index:TriggerFilter": {
+      "properties": { "event": { "type": "string", "language": { "csharp": { "name": "EventName" } } } },
+      "type": "object",
+      "required": ["event"],
+      "language": { "csharp": { "name": "EventFilter" } }
+    },
+    "test:index:WrappedTag": { "properties": { "key": { "type": "string" } }, "type": "object", "required": ["key"] }
   },
   "provider": {
     "description": "The provider configuration.",
@@ -284,6 +359,282 @@ func TestGetSchema(t *testing.T) {
         "s": { "type": "string", "default": "one" }
       },
       "requiredInputs": ["nestedPtr"]
+    },
+    "test:index:Renamed": {
+      "properties": { "arn": { "type": "string" }, "name": { "type": "string", "replaceOnChanges": true } },
+      "required": ["name", "arn"],
+      "inputProperties": {
+        "bucketName": {
+          "type": "string",
+          "deprecationMessage": "bucketName has been renamed to name.",
+          "replaceOnChanges": true
+        },
+        "name": { "type": "string", "replaceOnChanges": true }
+      },
+      "requiredInputs": ["name"]
+    },
+    "test:index:VersionedR": {
+      "properties": { "secret": { "type": "string" }, "sizeGb": { "type": "integer" } },
+      "required": ["sizeGb"],
+      "inputProperties": { "sizeGb": { "type": "integer" } },
+      "requiredInputs": ["sizeGb"],
+      "stateInputs": {
+        "description": "Input properties used for looking up and filtering resources.",
+        "properties": { "secret": { "type": "string" }, "sizeGb": { "type": "integer" } },
+        "type": "object"
+      }
+    },
+    "test:index:AutoNamed": {
+      "properties": { "name": { "type": "string" }, "size": { "type": "integer" } },
+      "inputProperties": { "name": { "type": "string", "replaceOnChanges": true }, "size": { "type": "integer" } }
+    },
+    "test:index:Region": {
+      "properties": { "endpoint": { "type": "string" }, "name": { "type": "string" } },
+      "required": ["name", "endpoint"],
+      "stateInputs": {
+        "description": "Input properties used for looking up and filtering resources.",
+        "properties": { "endpoint": { "type": "string" }, "name": { "type": "string" } },
+        "type": "object"
+      }
+    },
+    "test:index:Unreadable": {
+      "properties": { "value": { "type": "string" } },
+      "required": ["value"],
+      "inputProperties": { "value": { "type": "string" } },
+      "requiredInputs": ["value"]
+    },
+    "test:index:Previewed": {
+      "properties": {
+        "arn": { "type": "string" },
+        "createdAt": { "type": "string" },
+        "name": { "type": "string" },
+        "password": { "type": "string", "secret": true },
+        "public": { "type": "boolean" },
+        "revision": { "type": "integer" }
+      },
+      "required": ["name", "arn", "createdAt", "public", "revision"],
+      "inputProperties": { "name": { "type": "string" }, "password": { "type": "string", "secret": true } },
+      "requiredInputs": ["name"]
+    },
+    "test:index:Deployment": {
+      "properties": {
+        "replica": { "oneOf": [{ "type": "string" }, { "type": "number" }] },
+        "source": {
+          "oneOf": [
+            { "$ref": "#/types/test:index:BucketSource" },
+            { "$ref": "#/types/test:index:RepoSource" }
+          ]
+        },
+        "trigger": {
+          "oneOf": [
+            { "$ref": "#/types/test:index:PushTrigger" },
+            { "$ref": "#/types/test:index:CronTrigger" }
+          ],
+          "discriminator": {
+            "propertyName": "kind",
+            "mapping": {
+              "cron": "#/types/test:index:CronTrigger",
+              "push": "#/types/test:index:PushTrigger"
+            }
+          }
+        }
+      },
+      "required": ["source"],
+      "inputProperties": {
+        "replica": { "oneOf": [{ "type": "string" }, { "type": "number" }] },
+        "source": {
+          "oneOf": [
+            { "$ref": "#/types/test:index:BucketSource" },
+            { "$ref": "#/types/test:index:RepoSource" }
+          ]
+        },
+        "trigger": {
+          "oneOf": [
+            { "$ref": "#/types/test:index:PushTrigger" },
+            { "$ref": "#/types/test:index:CronTrigger" }
+          ],
+          "discriminator": {
+            "propertyName": "kind",
+            "mapping": {
+              "cron": "#/types/test:index:CronTrigger",
+              "push": "#/types/test:index:PushTrigger"
+            }
+          }
+        }
+      },
+      "requiredInputs": ["source"]
+    },
+    "test:index:Schedule": {
+      "properties": {
+        "interval": { "type": "string" },
+        "listen": { "type": "string" },
+        "next": { "type": "string" },
+        "retries": { "type": "array", "items": { "type": "string" } },
+        "start": { "type": "string" }
+      },
+      "required": ["interval", "start", "next"],
+      "inputProperties": {
+        "interval": { "type": "string" },
+        "listen": { "type": "string" },
+        "retries": { "type": "array", "items": { "type": "string" } },
+        "start": { "type": "string" }
+      },
+      "requiredInputs": ["interval", "start"]
+    },
+    "test:index:Listener": {
+      "properties": {
+        "backlog": { "type": "integer", "description": "Must be between -2147483648 and 2147483647." },
+        "count": { "type": "integer" },
+        "port": {
+          "type": "integer",
+          "description": "The port to listen on.\n\nMust be between 0 and 65535.",
+          "default": 8080
+        },
+        "priority": { "$ref": "#/types/test:index:Priority" },
+        "ratio": {
+          "type": "number",
+          "description": "Must be between -3.4028234663852886e+38 and 3.4028234663852886e+38."
+        }
+      },
+      "required": ["ratio", "priority", "count"],
+      "inputProperties": {
+        "backlog": { "type": "integer", "description": "Must be between -2147483648 and 2147483647." },
+        "count": { "type": "integer" },
+        "port": {
+          "type": "integer",
+          "description": "The port to listen on.\n\nMust be between 0 and 65535.",
+          "default": 8080
+        },
+        "priority": { "$ref": "#/types/test:index:Priority" },
+        "ratio": {
+          "type": "number",
+          "description": "Must be between -3.4028234663852886e+38 and 3.4028234663852886e+38."
+        }
+      },
+      "requiredInputs": ["ratio", "priority", "count"]
+    },
+    "test:index:Fleet": {
+      "properties": {
+        "hosts": { "type": "object", "additionalProperties": { "type": "string" } },
+        "regions": {
+          "type": "object",
+          "additionalProperties": { "$ref": "#/types/test:index:FleetConfig" }
+        },
+        "weights": { "type": "object", "additionalProperties": { "type": "number" } }
+      },
+      "required": ["regions"],
+      "inputProperties": {
+        "hosts": { "type": "object", "additionalProperties": { "type": "string" } },
+        "regions": {
+          "type": "object",
+          "additionalProperties": { "$ref": "#/types/test:index:FleetConfig" }
+        },
+        "weights": { "type": "object", "additionalProperties": { "type": "number" } }
+      },
+      "requiredInputs": ["regions"]
+    },
+    "test:index:Policy": {
+      "properties": {
+        "document": { "$ref": "pulumi.json#/Json" },
+        "metadata": { "$ref": "pulumi.json#/Json" },
+        "statements": { "type": "integer" }
+      },
+      "required": ["document", "statements"],
+      "inputProperties": {
+        "document": { "$ref": "pulumi.json#/Json" },
+        "metadata": { "$ref": "pulumi.json#/Json" }
+      },
+      "requiredInputs": ["document"]
+    },
+    "test:index:Network": {
+      "properties": { "cidr": { "type": "string" }, "gateway": { "type": "string" } },
+      "required": ["cidr", "gateway"],
+      "inputProperties": { "cidr": { "type": "string" } },
+      "requiredInputs": ["cidr"]
+    },
+    "test:index:Subnet": {
+      "properties": {
+        "gateway": { "type": "string" },
+        "network": { "$ref": "#/resources/test:index:Network" },
+        "networkId": { "type": "string" }
+      },
+      "required": ["network", "networkId"],
+      "inputProperties": { "network": { "$ref": "#/resources/test:index:Network" } },
+      "requiredInputs": ["network"]
+    },
+    "test:index:Catalog": {
+      "properties": {
+        "featured": { "$ref": "#/types/test:index:TaggedProduct" },
+        "labels": { "$ref": "#/types/test:index:TaggedString" },
+        "products": { "$ref": "#/types/test:index:PaginatedOfProduct" },
+        "tags": { "$ref": "#/types/test:index:PaginatedOfStringArray" }
+      },
+      "required": ["featured", "labels", "products", "tags"],
+      "inputProperties": {
+        "featured": { "$ref": "#/types/test:index:TaggedProduct" },
+        "labels": { "$ref": "#/types/test:index:TaggedString" }
+      },
+      "requiredInputs": ["featured", "labels"]
+    },
+    "test:index:Volume": {
+      "description": "Volume is a block device.",
+      "properties": {
+        "mount": { "$ref": "#/types/test:index:VolumeMount" },
+        "options": { "$ref": "#/types/test:index:VolumeOption" },
+        "size": { "type": "integer", "description": "The size in GiB." }
+      },
+      "required": ["size", "mount"],
+      "inputProperties": {
+        "mount": { "$ref": "#/types/test:index:VolumeMount" },
+        "options": { "$ref": "#/types/test:index:VolumeOption" },
+        "size": { "type": "integer", "description": "The size in GiB." }
+      },
+      "requiredInputs": ["size", "mount"]
+    },
+    "test:index:Trigger": {
+      "properties": {
+        "filter": { "$ref": "#/types/test:index:TriggerFilter" },
+        "lambda": {
+          "type": "string",
+          "language": { "python": { "name": "lambda_" }, "csharp": { "name": "LambdaArn" } }
+        }
+      },
+      "required": ["lambda", "filter"],
+      "language": { "csharp": { "name": "EventTrigger" } },
+      "inputProperties": {
+        "filter": { "$ref": "#/types/test:index:TriggerFilter" },
+        "lambda": {
+          "type": "string",
+          "language": { "python": { "name": "lambda_" }, "csharp": { "name": "LambdaArn" } }
+        }
+      },
+      "requiredInputs": ["lambda", "filter"]
+    },
+    "test:index:MigrateAutoNamedR": {
+      "properties": { "name": { "type": "string" } },
+      "inputProperties": { "name": { "type": "string", "replaceOnChanges": true } }
+    },
+    "test:index:MigrateInputsR": {
+      "properties": { "replicas": { "type": "integer" }, "sizeGb": { "type": "integer" } },
+      "required": ["sizeGb"],
+      "inputProperties": { "replicas": { "type": "integer" }, "sizeGb": { "type": "integer" } },
+      "requiredInputs": ["sizeGb"]
+    },
+    "test:index:Wrapped": {
+      "properties": {
+        "count": { "type": "integer" },
+        "derived": { "type": "string" },
+        "source": { "type": "string" },
+        "tags": { "type": "array", "items": { "$ref": "#/types/test:index:WrappedTag" } },
+        "total": { "type": "integer" }
+      },
+      "required": ["source", "derived", "total"],
+      "inputProperties": {
+        "count": { "type": "integer" },
+        "source": { "type": "string" },
+        "tags": { "type": "array", "items": { "$ref": "#/types/test:index:WrappedTag" } }
+      },
+      "requiredInputs": ["source"]
     }
   },
   "functions": {
@@ -301,6 +652,19 @@ func TestGetSchema(t *testing.T) {
         "type": "object",
         "required": ["result"]
       }
+    },
+    "test:index:listTriggers": {
+      "inputs": {
+        "properties": { "lambda": { "type": "string" } },
+        "type": "object",
+        "required": ["lambda"]
+      },
+      "language": { "go": { "name": "ListEventTriggers" } },
+      "outputs": {
+        "properties": { "lambda": { "type": "string" } },
+        "type": "object",
+        "required": ["lambda"]
+      }
     }
   }
 }
@@ -0,0 +1,139 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type (
+	Schedule     struct{}
+	ScheduleArgs struct {
+		Interval time.Duration   `pulumi:"interval"`
+		Start    time.Time       `pulumi:"start"`
+		Listen   *net.IP         `pulumi:"listen,optional"`
+		Retries  []time.Duration `pulumi:"retries,optional"`
+	}
+	ScheduleState struct {
+		ScheduleArgs
+		Next time.Time `pulumi:"next"`
+	}
+)
+
+func (*Schedule) Create(
+	_ context.Context, req infer.CreateRequest[ScheduleArgs],
+) (infer.CreateResponse[ScheduleState], error) {
+	return infer.CreateResponse[ScheduleState]{
+		ID: req.Name,
+		Output: ScheduleState{
+			ScheduleArgs: req.Inputs,
+			Next:         req.Inputs.Start.Add(req.Inputs.Interval),
+		},
+	}, nil
+}
+
+func TestTextSchema(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			InputProperties json.RawMessage `json:"inputProperties"`
+			Properties      json.RawMessage `json:"properties"`
+		} `json:"resources"`
+		Types map[string]json.RawMessage `json:"types"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	assert.JSONEq(t, `{
+		"interval": {"type": "string"},
+		"start": {"type": "string"},
+		"listen": {"type": "string"},
+		"retries": {"type": "array", "items": {"type": "string"}}
+	}`, string(spec.Resources["test:index:Schedule"].InputProperties))
+	assert.JSONEq(t, `{
+		"interval": {"type": "string"},
+		"start": {"type": "string"},
+		"listen": {"type": "string"},
+		"retries": {"type": "array", "items": {"type": "string"}},
+		"next": {"type": "string"}
+	}`, string(spec.Resources["test:index:Schedule"].Properties))
+	for tk := range spec.Types {
+		assert.NotContains(t, []string{"Duration", "Time", "IP"}, tokens.Type(tk).Name().String(),
+			"text types should not be registered as types")
+	}
+}
+
+func TestText(t *testing.T) {
+	t.Parallel()
+
+	t.Run("check", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Check(p.CheckRequest{
+			Urn: urn("Schedule", "s"),
+			Inputs: property.NewMap(map[string]property.Value{
+				"interval": property.New("every day"),
+				"start":    property.New("yesterday"),
+				"listen":   property.New("10.0.0.1"),
+			}),
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []p.CheckFailure{
+			{
+				Property: "interval",
+				Reason:   `invalid duration "every day": expected a value such as "1h30m"`,
+			},
+			{
+				Property: "start",
+				Reason: `invalid time.Time "yesterday": ` +
+					`parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`,
+			},
+		}, resp.Failures)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		inputs := map[string]property.Value{
+			"interval": property.New("1h0m0s"),
+			"start":    property.New("2024-05-01T12:00:00Z"),
+			"listen":   property.New("10.0.0.1"),
+			"retries":  property.New([]property.Value{property.New("5s")}),
+		}
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn:        urn("Schedule", "s"),
+			Properties: property.NewMap(inputs),
+		})
+		require.NoError(t, err)
+
+		inputs["next"] = property.New("2024-05-01T13:00:00Z")
+		assert.Equal(t, property.NewMap(inputs), resp.Properties)
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi-go-provider/integration"
+)
+
+type (
+	BucketResource     struct{}
+	BucketResourceArgs struct {
+		Rules []BucketRule `pulumi:"rules"`
+	}
+	BucketRule struct {
+		Prefix string `pulumi:"prefix"`
+	}
+
+	LookupBucketResource     struct{}
+	LookupBucketResourceArgs struct {
+		Name string `pulumi:"name"`
+	}
+	LookupBucketResourceResult struct {
+		Rules []BucketRule `pulumi:"rules"`
+	}
+)
+
+func (*BucketResource) Create(
+	_ context.Context, req infer.CreateRequest[BucketResourceArgs],
+) (infer.CreateResponse[BucketResourceArgs], error) {
+	return infer.CreateResponse[BucketResourceArgs]{ID: req.Name, Output: req.Inputs}, nil
+}
+
+func (*LookupBucketResource) Invoke(
+	_ context.Context, req infer.FunctionRequest[LookupBucketResourceArgs],
+) (infer.FunctionResponse[LookupBucketResourceResult], error) {
+	return infer.FunctionResponse[LookupBucketResourceResult]{
+		Output: LookupBucketResourceResult{Rules: []BucketRule{{Prefix: req.Input.Name}}},
+	}, nil
+}
+
+func tokenNamerProvider(t *testing.T) integration.Server {
+	opts := infer.NewProviderBuilder().
+		WithResources(infer.Resource(&BucketResource{})).
+		WithFunctions(infer.Function(&LookupBucketResource{})).
+		WithTokenNamer(infer.TrimTokenNamer(infer.FixedModuleTokenNamer("storage"), "", "Resource")).
+		WithModuleMap(map[tokens.ModuleName]tokens.ModuleName{"storage": "index"}).
+		BuildOptions()
+	s, err := integration.NewServer(t.Context(), "test", semver.MustParse("1.0.0"),
+		integration.WithProvider(infer.Provider(opts)))
+	require.NoError(t, err)
+	return s
+}
+
+func TestTokenNamerSchema(t *testing.T) {
+	t.Parallel()
+
+	resp, err := tokenNamerProvider(t).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			InputProperties json.RawMessage `json:"inputProperties"`
+		} `json:"resources"`
+		Functions map[string]json.RawMessage `json:"functions"`
+		Types     map[string]json.RawMessage `json:"types"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	assert.Contains(t, spec.Resources, "test:index:Bucket")
+	assert.Contains(t, spec.Functions, "test:index:lookupBucket")
+	assert.Contains(t, spec.Types, "test:index:BucketRule")
+	assert.JSONEq(t, `{
+		"rules": {
+			"type": "array",
+			"items": {"$ref": "#/types/test:index:BucketRule"}
+		}
+	}`, string(spec.Resources["test:index:Bucket"].InputProperties))
+
+	// Each provider names the same types with its own namer.
+	other, err := integration.NewServer(t.Context(), "test", semver.MustParse("1.0.0"),
+		integration.WithProvider(infer.Provider(infer.Options{
+			Resources:  []infer.InferredResource{infer.Resource(&BucketResource{})},
+			TokenNamer: infer.FixedModuleTokenNamer("other"),
+		})))
+	require.NoError(t, err)
+	resp, err = other.GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+	spec.Resources, spec.Types = nil, nil
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+	assert.Contains(t, spec.Resources, "test:other:BucketResource")
+	assert.Contains(t, spec.Types, "test:other:BucketRule")
+}
+
+func TestTokenNamerDispatch(t *testing.T) {
+	t.Parallel()
+
+	rules := property.New([]property.Value{
+		property.New(map[string]property.Value{"prefix": property.New("logs/")}),
+	})
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := tokenNamerProvider(t).Create(p.CreateRequest{
+			Urn:        urn("Bucket", "b"),
+			Properties: property.NewMap(map[string]property.Value{"rules": rules}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "b", resp.ID)
+	})
+
+	t.Run("invoke", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := tokenNamerProvider(t).Invoke(p.InvokeRequest{
+			Token: "test:index:lookupBucket",
+			Args:  property.NewMap(map[string]property.Value{"name": property.New("logs/")}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, property.NewMap(map[string]property.Value{"rules": rules}), resp.Return)
+	})
+}
+
+func TestTokenNamerInvalid(t *testing.T) {
+	t.Parallel()
+
+	// invalidFor gives the type named name an invalid token.
+	invalidFor := func(name string) infer.TokenNamer {
+		return func(t reflect.Type) (tokens.ModuleName, tokens.TypeName) {
+			mod, tk := infer.DefaultTokenNamer(t)
+			if t.Name() == name {
+				return "not a module", tk
+			}
+			return mod, tk
+		}
+	}
+
+	t.Run("build", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := infer.NewProviderBuilder().
+			WithResources(infer.Resource(&BucketResource{})).
+			WithTokenNamer(invalidFor("BucketResource")).
+			Build()
+		assert.ErrorContains(t, err, `TokenNamer gave tests.BucketResource the module "not a module"`)
+	})
+
+	t.Run("schema", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := integration.NewServer(t.Context(), "test", semver.MustParse("1.0.0"),
+			integration.WithProvider(infer.Provider(infer.Options{
+				Resources:  []infer.InferredResource{infer.Resource(&BucketResource{})},
+				TokenNamer: invalidFor("BucketRule"),
+			})))
+		require.NoError(t, err)
+		_, err = s.GetSchema(p.GetSchemaRequest{})
+		assert.ErrorContains(t, err, `TokenNamer gave tests.BucketRule the module "not a module"`)
+	})
+}
@@ -0,0 +1,195 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type (
+	Deployment     struct{}
+	DeploymentArgs struct {
+		Source  infer.Union2[BucketSource, RepoSource]  `pulumi:"source"`
+		Trigger *infer.Union2[PushTrigger, CronTrigger] `pulumi:"trigger,optional"`
+		Replica infer.Union2[string, float64]           `pulumi:"replica,optional"`
+	}
+
+	BucketSource struct {
+		Bucket string `pulumi:"bucket"`
+	}
+	RepoSource struct {
+		Repo string  `pulumi:"repo"`
+		Ref  *string `pulumi:"ref,optional"`
+	}
+
+	PushTrigger struct {
+		Kind   string `pulumi:"kind"`
+		Branch string `pulumi:"branch"`
+	}
+	CronTrigger struct {
+		Kind     string `pulumi:"kind"`
+		Schedule string `pulumi:"schedule"`
+	}
+)
+
+func (PushTrigger) UnionDiscriminator() (string, string) { return "kind", "push" }
+func (CronTrigger) UnionDiscriminator() (string, string) { return "kind", "cron" }
+
+func (*Deployment) Create(
+	_ context.Context, req infer.CreateRequest[DeploymentArgs],
+) (infer.CreateResponse[DeploymentArgs], error) {
+	return infer.CreateResponse[DeploymentArgs]{ID: req.Name, Output: req.Inputs}, nil
+}
+
+func TestUnionSchema(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			InputProperties map[string]json.RawMessage `json:"inputProperties"`
+		} `json:"resources"`
+		Types map[string]json.RawMessage `json:"types"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	props := spec.Resources["test:index:Deployment"].InputProperties
+	assert.JSONEq(t, `{
+		"oneOf": [
+			{"$ref": "#/types/test:index:BucketSource"},
+			{"$ref": "#/types/test:index:RepoSource"}
+		]
+	}`, string(props["source"]))
+	assert.JSONEq(t, `{
+		"oneOf": [
+			{"$ref": "#/types/test:index:PushTrigger"},
+			{"$ref": "#/types/test:index:CronTrigger"}
+		],
+		"discriminator": {
+			"propertyName": "kind",
+			"mapping": {
+				"push": "#/types/test:index:PushTrigger",
+				"cron": "#/types/test:index:CronTrigger"
+			}
+		}
+	}`, string(props["trigger"]))
+	assert.JSONEq(t, `{
+		"oneOf": [{"type": "string"}, {"type": "number"}]
+	}`, string(props["replica"]))
+
+	for _, typ := range []string{"BucketSource", "RepoSource", "PushTrigger", "CronTrigger"} {
+		assert.Contains(t, spec.Types, "test:index:"+typ)
+	}
+	for tk := range spec.Types {
+		assert.NotContains(t, tk, "Union", "unions should not be registered as types")
+	}
+}
+
+func TestUnion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("check", func(t *testing.T) {
+		t.Parallel()
+
+		inputs := property.NewMap(map[string]property.Value{
+			"source": property.New(map[string]property.Value{
+				"repo": property.New("github.com/pulumi/pulumi"),
+			}),
+			"trigger": property.New(map[string]property.Value{
+				"kind":     property.New("cron"),
+				"schedule": property.New("@daily"),
+			}),
+			"replica": property.New(2.0),
+		})
+		resp, err := provider(t).Check(p.CheckRequest{
+			Urn:    urn("Deployment", "d"),
+			Inputs: inputs,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Failures)
+		assert.Equal(t, inputs, resp.Inputs)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Check(p.CheckRequest{
+			Urn: urn("Deployment", "d"),
+			Inputs: property.NewMap(map[string]property.Value{
+				"source": property.New(map[string]property.Value{
+					"url": property.New("https://example.com"),
+				}),
+			}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []p.CheckFailure{{
+			Property: "source",
+			Reason:   "value does not match any of tests.BucketSource, tests.RepoSource",
+		}}, resp.Failures)
+	})
+
+	t.Run("unknown discriminator", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Check(p.CheckRequest{
+			Urn: urn("Deployment", "d"),
+			Inputs: property.NewMap(map[string]property.Value{
+				"source": property.New(map[string]property.Value{
+					"bucket": property.New("b"),
+				}),
+				"trigger": property.New(map[string]property.Value{
+					"kind": property.New("webhook"),
+				}),
+			}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []p.CheckFailure{{
+			Property: "trigger",
+			Reason:   `"kind" must be one of "push", "cron", found webhook`,
+		}}, resp.Failures)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		props := property.NewMap(map[string]property.Value{
+			"source": property.New(map[string]property.Value{
+				"bucket": property.New("b"),
+			}),
+			"trigger": property.New(map[string]property.Value{
+				"kind":   property.New("push"),
+				"branch": property.New("main"),
+			}),
+			"replica": property.New("primary"),
+		})
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn:        urn("Deployment", "d"),
+			Properties: props,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, props, resp.Properties)
+	})
+}
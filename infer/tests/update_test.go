@@ -15,12 +15,17 @@
 package tests
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/property"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 
 	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
 )
 
 //nolint:lll
@@ -239,3 +244,89 @@ func TestUpdateDefaultDeps(t *testing.T) {
 		)
 	})
 }
+
+func TestUpdateIsUnknown(t *testing.T) {
+	t.Parallel()
+
+	r := NewMockTestResource[WiredInputs, WiredOutputs](gomock.NewController(t))
+	r.EXPECT().Annotate(gomock.Any()).DoAndReturn(func(a infer.Annotator) {
+		a.SetToken("index", "Unknowns")
+	}).AnyTimes()
+	r.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(
+		_ context.Context, req infer.UpdateRequest[WiredInputs, WiredOutputs],
+	) (infer.UpdateResponse[WiredOutputs], error) {
+		assert.False(t, req.IsUnknown(&req.Inputs.String))
+		assert.True(t, req.IsUnknown(&req.Inputs.Int))
+		assert.Zero(t, req.Inputs.Int)
+		return infer.UpdateResponse[WiredOutputs]{Output: req.State}, nil
+	})
+
+	prov, err := infer.NewProviderBuilder().WithResources(infer.Resource(r)).Build()
+	require.NoError(t, err)
+
+	_, err = prov.Update(t.Context(), p.UpdateRequest{
+		ID:  "id",
+		Urn: urn("Unknowns", "preview"),
+		State: property.NewMap(map[string]property.Value{
+			"name":         property.New("id"),
+			"stringPlus":   property.New("str+"),
+			"stringAndInt": property.New("str-1"),
+		}),
+		Inputs: property.NewMap(map[string]property.Value{
+			"string": property.New("str"),
+			"int":    property.New(property.Computed),
+		}),
+		DryRun: true,
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateDefaultTimeout(t *testing.T) {
+	t.Parallel()
+
+	test := func(t *testing.T, engineTimeout float64, expected time.Duration) {
+		r := NewMockTestResource[WiredInputs, WiredOutputs](gomock.NewController(t))
+		r.EXPECT().Annotate(gomock.Any()).DoAndReturn(func(a infer.Annotator) {
+			a.SetToken("index", "Timeouts")
+			a.SetDefaultTimeouts(0, time.Hour, 0)
+		}).AnyTimes()
+		r.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(
+			ctx context.Context, req infer.UpdateRequest[WiredInputs, WiredOutputs],
+		) (infer.UpdateResponse[WiredOutputs], error) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			assert.Equal(t, deadline, req.Deadline)
+			assert.WithinDuration(t, time.Now().Add(expected), req.Deadline, time.Minute)
+			return infer.UpdateResponse[WiredOutputs]{Output: req.State}, nil
+		})
+
+		prov, err := infer.NewProviderBuilder().WithResources(infer.Resource(r)).Build()
+		require.NoError(t, err)
+
+		_, err = prov.Update(t.Context(), p.UpdateRequest{
+			ID:  "id",
+			Urn: urn("Timeouts", "update"),
+			State: property.NewMap(map[string]property.Value{
+				"name":         property.New("id"),
+				"stringPlus":   property.New("str+"),
+				"stringAndInt": property.New("str-1"),
+			}),
+			Inputs: property.NewMap(map[string]property.Value{
+				"string": property.New("str"),
+				"int":    property.New(2.0),
+			}),
+			Timeout: engineTimeout,
+		})
+		assert.NoError(t, err)
+	}
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		test(t, 0, time.Hour)
+	})
+
+	t.Run("engine", func(t *testing.T) {
+		t.Parallel()
+		test(t, (10 * time.Minute).Seconds(), 10*time.Minute)
+	})
+}
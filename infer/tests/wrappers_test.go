@@ -0,0 +1,157 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type (
+	Wrapped     struct{}
+	WrappedArgs struct {
+		Source infer.Secret[string]       `pulumi:"source"`
+		Count  infer.Unknowable[*int]     `pulumi:"count,optional"`
+		Tags   []infer.Secret[WrappedTag] `pulumi:"tags,optional"`
+	}
+	WrappedTag struct {
+		Key string `pulumi:"key"`
+	}
+	WrappedState struct {
+		WrappedArgs
+		Derived infer.Secret[string]  `pulumi:"derived"`
+		Total   infer.Unknowable[int] `pulumi:"total"`
+	}
+)
+
+func (*Wrapped) Create(
+	_ context.Context, req infer.CreateRequest[WrappedArgs],
+) (infer.CreateResponse[WrappedState], error) {
+	state := WrappedState{
+		WrappedArgs: req.Inputs,
+		Derived: infer.Secret[string]{
+			Value:    req.Inputs.Source.Value + "-derived",
+			IsSecret: req.Inputs.Source.IsSecret,
+		},
+		Total: infer.Unknowable[int]{IsUnknown: req.Inputs.Count.IsUnknown},
+	}
+	if c := req.Inputs.Count.Value; c != nil {
+		state.Total.Value = *c * 2
+	}
+	return infer.CreateResponse[WrappedState]{ID: "id", Output: state}, nil
+}
+
+func TestWrappersSchema(t *testing.T) {
+	t.Parallel()
+
+	resp, err := provider(t).GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			InputProperties map[string]json.RawMessage `json:"inputProperties"`
+			Properties      map[string]json.RawMessage `json:"properties"`
+		} `json:"resources"`
+		Types map[string]json.RawMessage `json:"types"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	res := spec.Resources["test:index:Wrapped"]
+	assert.JSONEq(t, `{"type": "string"}`, string(res.InputProperties["source"]))
+	assert.JSONEq(t, `{"type": "integer"}`, string(res.InputProperties["count"]))
+	assert.JSONEq(t, `{"type": "array", "items": {"$ref": "#/types/test:index:WrappedTag"}}`,
+		string(res.InputProperties["tags"]))
+	assert.JSONEq(t, `{"type": "string"}`, string(res.Properties["derived"]))
+	assert.JSONEq(t, `{"type": "integer"}`, string(res.Properties["total"]))
+	assert.Contains(t, spec.Types, "test:index:WrappedTag")
+	for tk := range spec.Types {
+		assert.NotContains(t, tk, "Secret", "wrappers should not be registered as types")
+		assert.NotContains(t, tk, "Unknowable", "wrappers should not be registered as types")
+	}
+}
+
+func TestWrappersCreate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("secret", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn: urn("Wrapped", "secret"),
+			Properties: property.NewMap(map[string]property.Value{
+				"source": property.New("src").WithSecret(true),
+				"count":  property.New(2.0),
+				"tags": property.New([]property.Value{
+					property.New(map[string]property.Value{
+						"key": property.New("k"),
+					}).WithSecret(true),
+				}),
+			}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, property.NewMap(map[string]property.Value{
+			"source":  property.New("src").WithSecret(true),
+			"count":   property.New(2.0),
+			"derived": property.New("src-derived").WithSecret(true),
+			"total":   property.New(4.0),
+			"tags": property.New([]property.Value{
+				property.New(map[string]property.Value{
+					"key": property.New("k"),
+				}).WithSecret(true),
+			}),
+		}), resp.Properties)
+	})
+
+	t.Run("public", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn: urn("Wrapped", "public"),
+			Properties: property.NewMap(map[string]property.Value{
+				"source": property.New("src"),
+			}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, property.NewMap(map[string]property.Value{
+			"source":  property.New("src"),
+			"derived": property.New("src-derived"),
+			"total":   property.New(0.0),
+		}), resp.Properties)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := provider(t).Create(p.CreateRequest{
+			Urn: urn("Wrapped", "unknown"),
+			Properties: property.NewMap(map[string]property.Value{
+				"source": property.New("src"),
+				"count":  property.New(property.Computed),
+			}),
+			DryRun: true,
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.Properties.Get("count").IsComputed())
+		assert.True(t, resp.Properties.Get("total").IsComputed())
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+
+	"github.com/pulumi/pulumi-go-provider/internal/introspect"
+)
+
+// TokenNamer names the resources, components, functions and types of a provider, given
+// the Go type that defines them.
+//
+// A TokenNamer returns the module and the name of the token, such as "storage" and
+// "Bucket" for the token "pkg:storage:Bucket". The package is always the name of the
+// provider. The names of functions are then made to start with a lower case letter, as
+// they are by default.
+//
+// A TokenNamer does not apply to tokens set with [Annotator.SetToken], and is applied
+// before [Options.ModuleMap]. An invalid module or name is reported as an error by
+// [ProviderBuilder.Build] and by schema generation.
+type TokenNamer func(t reflect.Type) (tokens.ModuleName, tokens.TypeName)
+
+// DefaultTokenNamer is the [TokenNamer] used when none is set. The module is the last
+// element of the package path of t, or "index" for package main, and the name is the name
+// of t.
+func DefaultTokenNamer(t reflect.Type) (tokens.ModuleName, tokens.TypeName) {
+	tk, err := introspect.GetGenericToken("pkg", t, getAnnotated(t).GenericFormat)
+	contract.AssertNoErrorf(err, "failed to get token for %s", t)
+	return tk.Module().Name(), tk.Name()
+}
+
+// PackagePathTokenNamer returns a [TokenNamer] whose module is the package path of the
+// type relative to root, with its elements joined by "/". Types in root itself are in the
+// "index" module.
+//
+// For example, with root "example.com/provider", the type Bucket in the package
+// "example.com/provider/storage/v2" is named "pkg:storage/v2:Bucket".
+func PackagePathTokenNamer(root string) TokenNamer {
+	root = strings.TrimSuffix(root, "/")
+	return func(t reflect.Type) (tokens.ModuleName, tokens.TypeName) {
+		_, name := DefaultTokenNamer(t)
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		path := t.PkgPath()
+		switch {
+		case path == root || path == "main":
+			return "index", name
+		case root != "" && strings.HasPrefix(path, root+"/"):
+			path = strings.TrimPrefix(path, root+"/")
+		}
+		return tokens.ModuleName(path), name
+	}
+}
+
+// FixedModuleTokenNamer returns a [TokenNamer] that places every type in module.
+func FixedModuleTokenNamer(module tokens.ModuleName) TokenNamer {
+	return func(t reflect.Type) (tokens.ModuleName, tokens.TypeName) {
+		_, name := DefaultTokenNamer(t)
+		return module, name
+	}
+}
+
+// TrimTokenNamer returns a [TokenNamer] that removes prefix and suffix from the names
+// given by namer, such as the suffix "Resource" from "BucketResource". A name that would
+// become empty is left as is. If namer is nil, [DefaultTokenNamer] is used.
+func TrimTokenNamer(namer TokenNamer, prefix, suffix string) TokenNamer {
+	if namer == nil {
+		namer = DefaultTokenNamer
+	}
+	return func(t reflect.Type) (tokens.ModuleName, tokens.TypeName) {
+		mod, name := namer(t)
+		trimmed := strings.TrimSuffix(strings.TrimPrefix(name.String(), prefix), suffix)
+		if trimmed == "" {
+			return mod, name
+		}
+		return mod, tokens.TypeName(trimmed)
+	}
+}
+
+// token returns the token of t in pkg, as named by namer. An error is returned if namer
+// gives t an invalid module or name.
+func (namer TokenNamer) token(pkg tokens.Package, t reflect.Type) (tokens.Type, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	mod, name := namer(t)
+	if !tokens.IsQName(mod.String()) {
+		return "", fmt.Errorf("TokenNamer gave %s the module %q, which must comply with %s, but does not",
+			t, mod, tokens.QNameRegexp)
+	}
+	if !tokens.IsName(name.String()) {
+		return "", fmt.Errorf("TokenNamer gave %s the name %q, which must comply with %s, but does not",
+			t, name, tokens.NameRegexp)
+	}
+	return tokens.NewTypeToken(tokens.NewModuleToken(pkg, mod), name), nil
+}
+
+// nameTokens returns a copy of elements that name their tokens with namer.
+func nameTokens[T interface{ withTokenNamer(TokenNamer) T }](elements []T, namer TokenNamer) []T {
+	named := make([]T, len(elements))
+	for i, e := range elements {
+		named[i] = e.withTokenNamer(namer)
+	}
+	return named
+}
@@ -0,0 +1,102 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/stretchr/testify/assert"
+)
+
+type NamedResource struct{}
+
+func TestTokenNamers(t *testing.T) {
+	t.Parallel()
+
+	typ := reflect.TypeFor[*NamedResource]()
+
+	tests := []struct {
+		name   string
+		namer  TokenNamer
+		module tokens.ModuleName
+		typ    tokens.TypeName
+	}{
+		{"default", DefaultTokenNamer, "infer", "NamedResource"},
+		{
+			"package path",
+			PackagePathTokenNamer("github.com/pulumi/pulumi-go-provider"),
+			"infer", "NamedResource",
+		},
+		{"package root", PackagePathTokenNamer("github.com/pulumi/pulumi-go-provider/infer"), "index", "NamedResource"},
+		{"full package path", PackagePathTokenNamer(""), "github.com/pulumi/pulumi-go-provider/infer", "NamedResource"},
+		{"fixed module", FixedModuleTokenNamer("storage"), "storage", "NamedResource"},
+		{"trim to empty", TrimTokenNamer(nil, "Named", "Resource"), "infer", "NamedResource"},
+		{"trim suffix", TrimTokenNamer(FixedModuleTokenNamer("storage"), "", "Resource"), "storage", "Named"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			module, name := tt.namer(typ)
+			assert.Equal(t, tt.module, module)
+			assert.Equal(t, tt.typ, name)
+		})
+	}
+}
+
+type TokenedResource struct{}
+
+func (*TokenedResource) Annotate(a Annotator) { a.SetToken("other", "Tokened") }
+
+func TestGetTokenOf(t *testing.T) {
+	t.Parallel()
+
+	namer := FixedModuleTokenNamer("storage")
+	tk, err := getTokenOf(reflect.TypeFor[*NamedResource](), namer, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, tokens.Type("pkg:storage:NamedResource"), tk)
+
+	tk, err = getTokenOf(reflect.TypeFor[NamedResource](), namer, fnToken)
+	assert.NoError(t, err)
+	assert.Equal(t, tokens.Type("pkg:storage:namedResource"), tk)
+
+	tk, err = getTokenOf(reflect.TypeFor[NamedResource](), nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, tokens.Type("pkg:infer:NamedResource"), tk)
+
+	// Tokens set with SetToken are not named.
+	tk, err = getTokenOf(reflect.TypeFor[*TokenedResource](), namer, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, tokens.Type("pkg:other:Tokened"), tk)
+}
+
+func TestGetTokenOfInvalid(t *testing.T) {
+	t.Parallel()
+
+	typ := reflect.TypeFor[NamedResource]()
+
+	_, err := getTokenOf(typ, FixedModuleTokenNamer("my module"), nil)
+	assert.ErrorContains(t, err, `TokenNamer gave infer.NamedResource the module "my module", which must comply with`)
+
+	_, err = getTokenOf(typ, TrimTokenNamer(nil, "", "Resource"), nil)
+	assert.NoError(t, err)
+
+	_, err = getTokenOf(typ, func(reflect.Type) (tokens.ModuleName, tokens.TypeName) {
+		return "storage", "Named Resource"
+	}, nil)
+	assert.ErrorContains(t, err, `TokenNamer gave infer.NamedResource the name "Named Resource", which must comply with`)
+}
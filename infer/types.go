@@ -17,13 +17,14 @@ package infer
 import (
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 
 	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 
+	"github.com/pulumi/pulumi-go-provider/infer/internal/ende"
 	"github.com/pulumi/pulumi-go-provider/infer/types"
 	"github.com/pulumi/pulumi-go-provider/internal/introspect"
 	"github.com/pulumi/pulumi-go-provider/middleware/schema"
@@ -69,7 +70,10 @@ type enum struct {
 
 // isEnum detects if a type implements Enum[T] without naming T. There is no function to
 // do this in the `reflect` package, so we implement this manually.
-func isEnum(t reflect.Type) (enum, bool) {
+//
+// An error is returned if t is an enum whose token or values cannot be described by the
+// schema.
+func isEnum(t reflect.Type, namer TokenNamer) (enum, bool, error) {
 	// To Simplify, we ensure that `t` is not a pointer type.
 	for t.Kind() == reflect.Pointer {
 		t = t.Elem()
@@ -85,14 +89,14 @@ func isEnum(t reflect.Type) (enum, bool) {
 	// The input is the receiver.
 	if !ok || m.Type.NumIn() != 1 ||
 		m.Type.NumOut() != 1 || m.Type.Out(0).Kind() != reflect.Slice {
-		return enum{}, false
+		return enum{}, false, nil
 	}
 	// We have now found a method with the right name and basic signature. We check that
 	// it returns []EnumValue, by checking for implementation of a private method.
 	isCorrectMethod := m.Type.Out(0).Elem().
 		Implements(reflect.TypeOf(new(isEnumValue)).Elem())
 	if !isCorrectMethod {
-		return enum{}, false
+		return enum{}, false, nil
 	}
 
 	// We have found an enum.
@@ -116,20 +120,26 @@ func isEnum(t reflect.Type) (enum, bool) {
 	values := make([]EnumValue[any], result.Len())
 	for i := 0; i < result.Len(); i++ {
 		v := result.Index(i)
+		value, err := coerceToBase(v.FieldByName("Value"))
+		if err != nil {
+			return enum{}, true, fmt.Errorf("invalid value of enum %s: %w", t, err)
+		}
 		values[i] = EnumValue[any]{
-			Value:       coerceToBase(v.FieldByName("Value")),
+			Value:       value,
 			Description: v.FieldByName("Description").String(),
 			Name:        v.FieldByName("Name").String(),
 		}
 	}
 
-	tk, err := getTokenOf(t, nil)
-	contract.AssertNoErrorf(err, "failed to get token for enum: %s", t)
+	tk, err := getTokenOf(t, namer, nil)
+	if err != nil {
+		return enum{}, true, err
+	}
 
 	return enum{
 		token:  tk.String(),
 		values: values,
-	}, true
+	}, true, nil
 }
 
 // Take a enum type and return it's base type.
@@ -140,16 +150,25 @@ func isEnum(t reflect.Type) (enum, bool) {
 //	const foo Foo = "foo"
 //
 // The above would result in `coerseToBase(reflect.ValueOf(foo)) == string(foo)`.
-func coerceToBase(v reflect.Value) any {
+//
+// Integers are returned as an int, so an error is returned for unsigned values that do not
+// fit in an int.
+func coerceToBase(v reflect.Value) (any, error) {
 	switch v.Kind() {
 	case reflect.String:
-		return v.String()
+		return v.String(), nil
 	case reflect.Bool:
-		return v.Bool()
-	case reflect.Float64:
-		return v.Float()
-	case reflect.Int:
-		return int(v.Int())
+		return v.Bool(), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := v.Uint()
+		if u > math.MaxInt {
+			return nil, fmt.Errorf("%d is out of the range of integers", u)
+		}
+		return int(u), nil
 	default:
 		panic("Unexpected value")
 	}
@@ -190,7 +209,10 @@ func crawlTypes[T any](crawler Crawler) error {
 			t = nT
 		}
 		switch t.Kind() {
-		case reflect.String, reflect.Float64, reflect.Int, reflect.Bool:
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
 			// Primitive types could be enums
 			_, err := crawler(t, isReference, fieldInfo, "", "")
 			return err
@@ -220,6 +242,10 @@ func crawlTypes[T any](crawler Crawler) error {
 						typ = typ.Elem()
 						fieldIsReference = true
 					default:
+						if inner, ok := ende.Unwrap(typ); ok {
+							typ = inner
+							continue
+						}
 						nT, inputty, err := underlyingType(typ)
 						if err != nil {
 							errs = append(errs, err)
@@ -254,7 +280,7 @@ func crawlTypes[T any](crawler Crawler) error {
 }
 
 // registerTypes recursively examines fields of T, calling reg on the schematized type when appropriate.
-func registerTypes[T any](reg schema.RegisterDerivativeType) error {
+func registerTypes[T any](reg schema.RegisterDerivativeType, namer TokenNamer) error {
 	crawler := func(
 		t reflect.Type, isReference bool, info *introspect.FieldTag,
 		parent, field string,
@@ -272,7 +298,13 @@ func registerTypes[T any](reg schema.RegisterDerivativeType) error {
 		if t == reflect.TypeOf(types.AssetOrArchive{}) {
 			return false, nil
 		}
-		if enum, ok := isEnum(t); ok {
+		// Unions are described inline, but their variants may need to be registered.
+		if _, ok := ende.UnionVariants(t); ok {
+			return true, nil
+		}
+		if enum, ok, err := isEnum(t, namer); err != nil {
+			return false, err
+		} else if ok {
 			if info != nil && info.Optional && !isReference {
 				return false, optionalNeedsPointerError{
 					ParentStruct: parent,
@@ -294,17 +326,22 @@ func registerTypes[T any](reg schema.RegisterDerivativeType) error {
 			_ = reg(tokens.Type(enum.token), tSpec)
 			return false, nil
 		}
-		if _, ok, err := resourceReferenceToken(t, nil, true); ok {
+		if _, ok, err := resourceReferenceToken(t, nil, true, namer); ok {
 			// This will have already been registered, so we don't need to recurse here
 			return false, err
 		}
+		// JSON documents and text types are described by primitive types, so they have no
+		// fields to register.
+		if ende.IsJSON(t) || ende.IsText(t) {
+			return false, nil
+		}
 		if t.Kind() == reflect.Struct {
-			spec, err := objectSchema(t)
+			spec, err := objectSchema(t, namer)
 			if err != nil {
 				return false, err
 			}
 
-			tk, err := getTokenOf(t, nil)
+			tk, err := getTokenOf(t, namer, nil)
 			if err != nil {
 				return false, err
 			}
@@ -0,0 +1,63 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// JSON is an arbitrary JSON document, such as a policy or a set of labels whose shape is
+// not known to the provider.
+//
+// JSON is described in the schema as pulumi.json#/Json, so programs set it to an ordinary
+// value instead of a string. Numbers, strings, booleans, null, arrays and objects are kept
+// as they are, which is not possible with a field of type any. Values decoded from Pulumi
+// are compact, with the keys of objects sorted, so two values that hold the same document
+// have the same bytes.
+//
+// A field of type [encoding/json.RawMessage] behaves the same way.
+type JSON json.RawMessage
+
+// NewJSON returns the JSON encoding of v.
+func NewJSON(v any) (JSON, error) {
+	b, err := json.Marshal(v)
+	return JSON(b), err
+}
+
+// Unmarshal decodes the document held by j into v, as [encoding/json.Unmarshal] does.
+func (j JSON) Unmarshal(v any) error {
+	return json.Unmarshal(j, v)
+}
+
+// Equal reports if j and other hold the same document, regardless of formatting or the
+// order of the keys of objects.
+func (j JSON) Equal(other JSON) bool {
+	var a, b any
+	if json.Unmarshal(j, &a) != nil || json.Unmarshal(other, &b) != nil {
+		return string(j) == string(other)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// MarshalJSON returns j as the encoding of j.
+func (j JSON) MarshalJSON() ([]byte, error) {
+	return json.RawMessage(j).MarshalJSON()
+}
+
+// UnmarshalJSON sets *j to a copy of data.
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	return (*json.RawMessage)(j).UnmarshalJSON(data)
+}
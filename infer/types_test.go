@@ -15,6 +15,7 @@
 package infer
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
@@ -22,6 +23,7 @@ import (
 	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type MyEnum string
@@ -63,6 +65,12 @@ func (*EnumByRef) Values() []EnumValue[EnumByRef] {
 
 type NotAnEnum bool
 
+type LargeEnum uint64
+
+func (LargeEnum) Values() []EnumValue[LargeEnum] {
+	return []EnumValue[LargeEnum]{{Value: 1}, {Value: math.MaxUint64}}
+}
+
 func TestIsEnum(t *testing.T) {
 	t.Parallel()
 
@@ -119,7 +127,8 @@ func TestIsEnum(t *testing.T) {
 			}
 			t.Run(c.typ.String(), func(t *testing.T) {
 				t.Parallel()
-				enum, ok := isEnum(c.typ)
+				enum, ok, err := isEnum(c.typ, nil)
+				require.NoError(t, err)
 				if c.token == "" {
 					assert.False(t, ok)
 					return
@@ -132,6 +141,15 @@ func TestIsEnum(t *testing.T) {
 	}
 }
 
+func TestIsEnumOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	_, ok, err := isEnum(reflect.TypeFor[LargeEnum](), nil)
+	assert.True(t, ok)
+	assert.EqualError(t, err,
+		"invalid value of enum infer.LargeEnum: 18446744073709551615 is out of the range of integers")
+}
+
 type Foo struct {
 	Bar      *Bar   `pulumi:"bar"`
 	Enum     MyEnum `pulumi:"enum"`
@@ -162,7 +180,7 @@ func TestCrawlTypes(t *testing.T) {
 		m[typ.String()] = spec
 		return true
 	}
-	err := registerTypes[Foo](reg)
+	err := registerTypes[Foo](reg, nil)
 	assert.NoError(t, err)
 
 	assert.Equal(t,
@@ -228,10 +246,10 @@ func TestReservedFields(t *testing.T) {
 	reg := func(tokens.Type, pschema.ComplexTypeSpec) bool {
 		return true
 	}
-	err := registerTypes[outer](reg)
+	err := registerTypes[outer](reg, nil)
 	assert.NoError(t, err, "id isn't reserved on nested fields")
 
-	err = registerTypes[inner](reg)
+	err = registerTypes[inner](reg, nil)
 	assert.ErrorContains(t, err, `"id" is a reserved field name`)
 }
 
@@ -247,7 +265,7 @@ func noOpRegister() schema.RegisterDerivativeType {
 func registerOk[T any]() func(t *testing.T) {
 	return func(t *testing.T) {
 		t.Parallel()
-		err := registerTypes[T](noOpRegister())
+		err := registerTypes[T](noOpRegister(), nil)
 		assert.NoError(t, err)
 	}
 }
@@ -268,7 +286,7 @@ func TestInvalidOptionalProperty(t *testing.T) {
 
 	t.Run("invalid optional enum", func(t *testing.T) {
 		t.Parallel()
-		err := registerTypes[invalidContainsOptionalEnum](noOpRegister())
+		err := registerTypes[invalidContainsOptionalEnum](noOpRegister(), nil)
 
 		var actual optionalNeedsPointerError
 		if assert.ErrorAs(t, err, &actual) {
@@ -290,7 +308,7 @@ func TestInvalidOptionalProperty(t *testing.T) {
 
 	t.Run("invalid optional struct", func(t *testing.T) {
 		t.Parallel()
-		err := registerTypes[invalidContainsOptionalStruct](noOpRegister())
+		err := registerTypes[invalidContainsOptionalStruct](noOpRegister(), nil)
 
 		var actual optionalNeedsPointerError
 		if assert.ErrorAs(t, err, &actual) {
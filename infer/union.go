@@ -0,0 +1,70 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+// The struct tags below must match the signature in the ende package, which recognizes
+// unions by their shape.
+
+// Union2 holds a value that is either an A or a B. Exactly one of its fields is set.
+//
+// Union2[A, B] is described in the schema as oneOf A or B. When a value is decoded, the
+// variant it matches is set. A value that matches both variants is ambiguous, and is
+// reported as a check failure. To tell struct variants apart by the value of a property
+// instead, implement [UnionDiscriminator] on each variant:
+//
+//	type SourceArgs struct {
+//		Source infer.Union2[S3Source, GitSource] `pulumi:"source"`
+//	}
+//
+//	type S3Source struct {
+//		Bucket string `pulumi:"bucket"`
+//	}
+//
+//	type GitSource struct {
+//		Repo string  `pulumi:"repo"`
+//		Ref  *string `pulumi:"ref,optional"`
+//	}
+type Union2[A, B any] struct {
+	A *A `pulumi:"9c4bd6e1f05a2e7d3b8c61a4f2e90d570,optional"`
+	B *B `pulumi:"9c4bd6e1f05a2e7d3b8c61a4f2e90d571,optional"`
+}
+
+// Union3 holds a value that is an A, a B or a C. Exactly one of its fields is set.
+//
+// See [Union2] for details.
+type Union3[A, B, C any] struct {
+	A *A `pulumi:"9c4bd6e1f05a2e7d3b8c61a4f2e90d570,optional"`
+	B *B `pulumi:"9c4bd6e1f05a2e7d3b8c61a4f2e90d571,optional"`
+	C *C `pulumi:"9c4bd6e1f05a2e7d3b8c61a4f2e90d572,optional"`
+}
+
+// UnionDiscriminator is implemented by the struct variants of a union, such as [Union2],
+// that are identified by the value of one of their properties.
+//
+// If every variant of a union implements UnionDiscriminator with the same property, the
+// variant of a value is chosen by the value of that property, and the schema describes
+// the property as the discriminator of the union.
+//
+//	type S3Source struct {
+//		Type   string `pulumi:"type"`
+//		Bucket string `pulumi:"bucket"`
+//	}
+//
+//	func (S3Source) UnionDiscriminator() (string, string) { return "type", "s3" }
+type UnionDiscriminator interface {
+	// UnionDiscriminator returns the name of the discriminating property, and the value
+	// it holds for this variant.
+	UnionDiscriminator() (property, value string)
+}
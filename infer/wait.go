@@ -0,0 +1,133 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	p "github.com/pulumi/pulumi-go-provider"
+)
+
+// WaitConfig describes how [Wait] polls for a resource to reach a target state.
+type WaitConfig[T any] struct {
+	// Poll fetches the current value of the resource and the state it is in. Poll is
+	// required.
+	Poll func(ctx context.Context) (value T, state string, err error)
+	// Target is the set of states that end the wait successfully. Target must not be
+	// empty.
+	Target []string
+	// Pending is the set of states that continue the wait. If Pending is empty, any
+	// state not in Target continues the wait. Otherwise, reaching a state in neither
+	// Target nor Pending ends the wait with an error.
+	Pending []string
+
+	// MinDelay is the delay between the first two polls. It defaults to one second.
+	MinDelay time.Duration
+	// MaxDelay caps the delay between polls. It defaults to 30 seconds.
+	MaxDelay time.Duration
+}
+
+// Wait polls a resource until it reaches one of the states in config.Target.
+//
+// The delay between polls starts at config.MinDelay and doubles after each poll, up to
+// config.MaxDelay. Each delay is jittered to avoid polling in lock step with other
+// resources. Progress is reported to the user as a status message.
+//
+// Wait runs until ctx is done, which for resource operations means until the operation
+// times out or is canceled. If ctx times out, the last value polled is returned with a
+// [ResourceInitFailedError], so that it can be returned as the partial state of the
+// resource:
+//
+//	state, err := infer.Wait(ctx, infer.WaitConfig[State]{
+//		Poll: func(ctx context.Context) (State, string, error) {
+//			s, err := client.Get(ctx, id)
+//			return s, s.Status, err
+//		},
+//		Target:  []string{"ACTIVE"},
+//		Pending: []string{"PROVISIONING"},
+//	})
+//	return infer.CreateResponse[State]{ID: id, Output: state}, err
+func Wait[T any](ctx context.Context, config WaitConfig[T]) (T, error) {
+	var last T
+	if config.Poll == nil {
+		return last, errors.New("WaitConfig.Poll must be set")
+	}
+	if len(config.Target) == 0 {
+		return last, errors.New("WaitConfig.Target must not be empty")
+	}
+
+	minDelay, maxDelay := config.MinDelay, config.MaxDelay
+	if minDelay <= 0 {
+		minDelay = time.Second
+	}
+	if maxDelay <= 0 {
+		maxDelay = 30 * time.Second
+	}
+	maxDelay = max(minDelay, maxDelay)
+	target := strings.Join(config.Target, ", ")
+
+	var state string
+	for delay := minDelay; ; delay = min(2*delay, maxDelay) {
+		value, s, err := config.Poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, waitStopped(ctx, target, state)
+			}
+			return value, err
+		}
+		last, state = value, s
+
+		switch {
+		case slices.Contains(config.Target, state):
+			return value, nil
+		case len(config.Pending) > 0 && !slices.Contains(config.Pending, state):
+			return value, fmt.Errorf("unexpected state %q while waiting for %s", state, target)
+		}
+		p.GetLogger(ctx).InfoStatusf("Waiting for %s (currently %s)", target, state)
+
+		if !sleepWithJitter(ctx, delay) {
+			return last, waitStopped(ctx, target, state)
+		}
+	}
+}
+
+// sleepWithJitter sleeps for between half of delay and delay. It returns false if ctx
+// was done before the sleep finished.
+func sleepWithJitter(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay/2 + rand.N(delay/2+1)) //nolint:gosec // Jitter needs no secure source.
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// waitStopped describes why a [Wait] was stopped by ctx.
+func waitStopped(ctx context.Context, target, state string) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ResourceInitFailedError{Reasons: []string{
+			fmt.Sprintf("timed out waiting for %s, last state was %q", target, state),
+		}}
+	}
+	return ctx.Err()
+}
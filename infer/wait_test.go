@@ -0,0 +1,120 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWait(t *testing.T) {
+	t.Parallel()
+
+	// states returns a poll function that walks through states, repeating the last
+	// state once it is reached.
+	states := func(states ...string) func(context.Context) (int, string, error) {
+		var i int
+		return func(context.Context) (int, string, error) {
+			i = min(i+1, len(states))
+			return i, states[i-1], nil
+		}
+	}
+
+	t.Run("target", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := Wait(t.Context(), WaitConfig[int]{
+			Poll:     states("CREATING", "CREATING", "ACTIVE"),
+			Target:   []string{"ACTIVE"},
+			Pending:  []string{"CREATING"},
+			MinDelay: time.Millisecond,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, v)
+	})
+
+	t.Run("unexpected-state", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := Wait(t.Context(), WaitConfig[int]{
+			Poll:     states("CREATING", "FAILED"),
+			Target:   []string{"ACTIVE"},
+			Pending:  []string{"CREATING"},
+			MinDelay: time.Millisecond,
+		})
+		assert.ErrorContains(t, err, `unexpected state "FAILED" while waiting for ACTIVE`)
+		assert.Equal(t, 2, v)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+		v, err := Wait(ctx, WaitConfig[int]{
+			Poll:     states("CREATING", "UPDATING"),
+			Target:   []string{"ACTIVE"},
+			MinDelay: time.Millisecond,
+			MaxDelay: 2 * time.Millisecond,
+		})
+		var initFailed ResourceInitFailedError
+		require.ErrorAs(t, err, &initFailed)
+		assert.Equal(t, []string{`timed out waiting for ACTIVE, last state was "UPDATING"`}, initFailed.Reasons)
+		assert.Equal(t, 2, v)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		_, err := Wait(ctx, WaitConfig[int]{
+			Poll:   states("CREATING"),
+			Target: []string{"ACTIVE"},
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("poll-error", func(t *testing.T) {
+		t.Parallel()
+
+		pollErr := errors.New("not found")
+		_, err := Wait(t.Context(), WaitConfig[int]{
+			Poll: func(context.Context) (int, string, error) {
+				return 0, "", pollErr
+			},
+			Target: []string{"ACTIVE"},
+		})
+		assert.ErrorIs(t, err, pollErr)
+	})
+	t.Run("no-poll", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Wait(t.Context(), WaitConfig[int]{Target: []string{"ACTIVE"}})
+		assert.EqualError(t, err, "WaitConfig.Poll must be set")
+	})
+
+	t.Run("no-target", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Wait(t.Context(), WaitConfig[int]{Poll: states("CREATING")})
+		assert.EqualError(t, err, "WaitConfig.Target must not be empty")
+	})
+}
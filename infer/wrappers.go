@@ -0,0 +1,57 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+// The struct tags below must match the signatures in the ende package, which recognizes
+// value wrappers by their shape.
+
+// Secret holds a value of type T that may or may not be secret.
+//
+// Where `provider:"secret"` makes every value of a field secret, Secret tracks secretness
+// for each value: IsSecret is set when a secret value is decoded, and Value is marked as
+// secret when it is encoded with IsSecret set. This allows an output to be secret only
+// when the input it was derived from is secret:
+//
+//	func (*Resource) Create(
+//		ctx context.Context, req infer.CreateRequest[Args],
+//	) (infer.CreateResponse[State], error) {
+//		return infer.CreateResponse[State]{
+//			ID: "id",
+//			Output: State{
+//				Derived: infer.Secret[string]{
+//					Value:    derive(req.Inputs.Source.Value),
+//					IsSecret: req.Inputs.Source.IsSecret,
+//				},
+//			},
+//		}, nil
+//	}
+//
+// Secret[T] is described in the schema as T.
+type Secret[T any] struct {
+	Value    T    `pulumi:"234846baf2105a1f902a5f1c8fe3b9f8,optional"`
+	IsSecret bool `pulumi:"21ef42b078aa37125d2a77cff4360ed8,optional"`
+}
+
+// Unknowable holds a value of type T that may be unknown during a preview.
+//
+// IsUnknown is set when an unknown value is decoded, in which case Value holds the zero
+// value of T. When IsUnknown is set on an encoded value, the value is sent to the engine
+// as unknown. Unknown values may only be returned during a preview.
+//
+// Unknowable[T] is described in the schema as T.
+type Unknowable[T any] struct {
+	Value     T    `pulumi:"234846baf2105a1f902a5f1c8fe3b9f8,optional"`
+	IsUnknown bool `pulumi:"75f5259434a56871129a93096f17eb41,optional"`
+}
@@ -0,0 +1,322 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package describe generates calls to infer.RegisterDescriptions from the doc comments of
+// the types of a provider.
+package describe
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const inferPath = "github.com/pulumi/pulumi-go-provider/infer"
+
+// The functions of the infer package that take the types of a provider.
+var inferFunctions = map[string]bool{
+	"Resource":         true,
+	"ReadOnlyResource": true,
+	"Function":         true,
+	"Component":        true,
+	"ComponentF":       true,
+	"Config":           true,
+}
+
+// Generate returns the source of a file in the package in dir that registers the
+// descriptions of the types of the provider defined by the package.
+//
+// The types of the provider are the types passed to infer.Resource, infer.Function,
+// infer.Component and infer.Config, the types in the signatures of their methods and of
+// functions passed to infer.ComponentF, and the types of the fields of those types,
+// recursively. Only types declared in the package are described. The description of a type
+// is its doc comment, and the description of a field is the doc comment or the line
+// comment of the field.
+//
+// Generate reads the package without type checking it, so types are only found when they
+// are written as such at the call site, as in infer.Resource(&Bucket{}),
+// infer.Resource(Bucket{}), infer.Resource(new(Bucket)) or infer.Config[*Config](nil).
+// Test files, files excluded by build constraints and files that end in ".gen.go" are not
+// read.
+func Generate(dir string) ([]byte, error) {
+	pkg, err := parsePackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, root := range pkg.roots() {
+		pkg.use(root)
+	}
+
+	var body bytes.Buffer
+	for _, name := range slices.Sorted(maps.Keys(pkg.used)) {
+		descriptions := pkg.descriptions(pkg.types[name])
+		if len(descriptions) == 0 {
+			continue
+		}
+		fmt.Fprintf(&body, "infer.RegisterDescriptions[%s](map[string]string{\n", name)
+		for _, k := range slices.Sorted(maps.Keys(descriptions)) {
+			fmt.Fprintf(&body, "%s: %s,\n", strconv.Quote(k), strconv.Quote(descriptions[k]))
+		}
+		fmt.Fprintf(&body, "})\n")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by github.com/pulumi/pulumi-go-provider/cmd/describe; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n", pkg.name)
+	// Without any descriptions, the file only holds the package clause, so that it
+	// replaces a stale file.
+	if body.Len() > 0 {
+		fmt.Fprintf(&buf, "\nimport %q\n\nfunc init() {\n%s}\n", inferPath, body.String())
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// typeDecl is a type declared by the package.
+type typeDecl struct {
+	spec *ast.TypeSpec
+	doc  *ast.CommentGroup
+}
+
+type packageInfo struct {
+	name    string
+	files   []*ast.File
+	types   map[string]typeDecl
+	methods map[string][]*ast.FuncDecl
+	funcs   map[string]*ast.FuncDecl
+	used    map[string]bool
+}
+
+func parsePackage(dir string) (*packageInfo, error) {
+	bpkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &packageInfo{
+		name:    bpkg.Name,
+		types:   map[string]typeDecl{},
+		methods: map[string][]*ast.FuncDecl{},
+		funcs:   map[string]*ast.FuncDecl{},
+		used:    map[string]bool{},
+	}
+	fset := token.NewFileSet()
+	for _, name := range bpkg.GoFiles {
+		if strings.HasSuffix(name, ".gen.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		info.files = append(info.files, file)
+	}
+	for _, file := range info.files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				if decl.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.TypeSpec)
+					doc := spec.Doc
+					if doc == nil && len(decl.Specs) == 1 {
+						doc = decl.Doc
+					}
+					info.types[spec.Name.Name] = typeDecl{spec: spec, doc: doc}
+				}
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					info.funcs[decl.Name.Name] = decl
+					continue
+				}
+				if recv := receiverName(decl.Recv.List[0].Type); recv != "" {
+					info.methods[recv] = append(info.methods[recv], decl)
+				}
+			}
+		}
+	}
+	return info, nil
+}
+
+// roots returns the expressions that name the types passed to the infer package, and the
+// signatures of functions passed to infer.ComponentF.
+func (pkg *packageInfo) roots() []ast.Node {
+	var roots []ast.Node
+	for _, file := range pkg.files {
+		inferName := importName(file, inferPath)
+		if inferName == "" {
+			continue
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			fun, typeArgs := call.Fun, []ast.Expr(nil)
+			switch f := fun.(type) {
+			case *ast.IndexExpr:
+				fun, typeArgs = f.X, []ast.Expr{f.Index}
+			case *ast.IndexListExpr:
+				fun, typeArgs = f.X, f.Indices
+			}
+			sel, ok := fun.(*ast.SelectorExpr)
+			if !ok || !isIdent(sel.X, inferName) || !inferFunctions[sel.Sel.Name] {
+				return true
+			}
+			for _, arg := range typeArgs {
+				roots = append(roots, arg)
+			}
+			if len(call.Args) != 1 {
+				return true
+			}
+			switch arg := ast.Unparen(call.Args[0]).(type) {
+			case *ast.UnaryExpr:
+				if lit, ok := arg.X.(*ast.CompositeLit); ok && arg.Op == token.AND {
+					roots = append(roots, lit.Type)
+				}
+			case *ast.CompositeLit:
+				roots = append(roots, arg.Type)
+			case *ast.CallExpr:
+				if isIdent(arg.Fun, "new") && len(arg.Args) == 1 {
+					roots = append(roots, arg.Args[0])
+				}
+			case *ast.Ident:
+				if fn, ok := pkg.funcs[arg.Name]; ok {
+					roots = append(roots, fn.Type)
+				}
+			}
+			return true
+		})
+	}
+	return roots
+}
+
+// use marks the types named in n as used, along with the types they use.
+func (pkg *packageInfo) use(n ast.Node) {
+	if n == nil {
+		return
+	}
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			// A type from another package.
+			return false
+		case *ast.Field:
+			// Only the type of a field or a parameter names a type.
+			pkg.use(n.Type)
+			return false
+		case *ast.Ident:
+			decl, ok := pkg.types[n.Name]
+			if !ok || pkg.used[n.Name] {
+				return false
+			}
+			pkg.used[n.Name] = true
+			pkg.use(decl.spec.Type)
+			for _, m := range pkg.methods[n.Name] {
+				pkg.use(m.Type)
+			}
+		}
+		return true
+	})
+}
+
+// descriptions returns the descriptions of a type and its fields.
+func (pkg *packageInfo) descriptions(decl typeDecl) map[string]string {
+	descriptions := map[string]string{}
+	// Generic types cannot be registered without their type arguments.
+	if decl.spec.TypeParams != nil {
+		return descriptions
+	}
+	if text := commentText(decl.doc); text != "" {
+		descriptions[""] = text
+	}
+	st, ok := decl.spec.Type.(*ast.StructType)
+	if !ok {
+		return descriptions
+	}
+	for _, field := range st.Fields.List {
+		// Embedded fields are described by their own types.
+		if len(field.Names) != 1 || field.Tag == nil || !field.Names[0].IsExported() {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		pulumiTag, ok := reflect.StructTag(tag).Lookup("pulumi")
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(pulumiTag, ",")
+		text := commentText(field.Doc)
+		if text == "" {
+			text = commentText(field.Comment)
+		}
+		if name != "" && text != "" {
+			descriptions[name] = text
+		}
+	}
+	return descriptions
+}
+
+func commentText(doc *ast.CommentGroup) string {
+	return strings.TrimSpace(doc.Text())
+}
+
+func receiverName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(expr.X)
+	case *ast.IndexExpr:
+		return receiverName(expr.X)
+	case *ast.IndexListExpr:
+		return receiverName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	}
+	return ""
+}
+
+// importName returns the name that file uses for the package with the given path, or ""
+// if file does not import it.
+func importName(file *ast.File, path string) string {
+	for _, imp := range file.Imports {
+		if p, err := strconv.Unquote(imp.Path.Value); err != nil || p != path {
+			continue
+		}
+		if imp.Name != nil {
+			return imp.Name.Name
+		}
+		return filepath.Base(path)
+	}
+	return ""
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
@@ -0,0 +1,57 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package describe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join("testdata", "provider")
+	src, err := Generate(dir)
+	require.NoError(t, err)
+
+	// The expected output is checked in, which also shows that files ending in ".gen.go"
+	// are not read.
+	expected, err := os.ReadFile(filepath.Join(dir, "descriptions.gen.go"))
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(src))
+}
+
+func TestGenerateNothing(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte(`package main
+
+// T is not used by a provider.
+type T struct {
+	// F is a field.
+	F string `+"`pulumi:\"f\"`"+`
+}
+`), 0o600))
+
+	src, err := Generate(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "// Code generated by github.com/pulumi/pulumi-go-provider/cmd/describe; DO NOT EDIT.\n\n"+
+		"package main\n", string(src))
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func main() {
+	p.RunProvider(context.Background(), "storage", "0.1.0", provider())
+}
+
+func provider() p.Provider {
+	return infer.Provider(infer.Options{
+		Resources:  []infer.InferredResource{infer.Resource(&Bucket{})},
+		Functions:  []infer.InferredFunction{infer.Function(new(GetBucket))},
+		Components: []infer.InferredComponent{infer.ComponentF(NewSite)},
+		Config:     infer.Config[*Config](nil),
+	})
+}
+
+// Bucket is a storage bucket.
+//
+// Objects are kept until they are deleted.
+type Bucket struct{}
+
+// BucketArgs are the inputs of a bucket.
+type BucketArgs struct {
+	// The rules that apply to the objects of the bucket.
+	Rules  []Rule `pulumi:"rules,optional"`
+	Config string `pulumi:"config"` // The name of a configuration.
+	// Not described, because the field has no tag.
+	Untagged string
+	internal string `pulumi:"internal"` // Not described, because the field is not exported.
+}
+
+// BucketState is the state of a bucket.
+type BucketState struct {
+	BucketArgs
+	// The URL of the bucket.
+	URL string `pulumi:"url"`
+	// The versions of the bucket.
+	Versions Page[Version] `pulumi:"versions"`
+}
+
+func (*Bucket) Create(
+	ctx context.Context, req infer.CreateRequest[BucketArgs],
+) (infer.CreateResponse[BucketState], error) {
+	return infer.CreateResponse[BucketState]{}, nil
+}
+
+type (
+	// Rule selects objects of a bucket.
+	Rule struct {
+		// Objects whose keys start with prefix.
+		Prefix string `pulumi:"prefix"`
+	}
+
+	// Page is not described, since it is generic.
+	Page[T any] struct {
+		Items []T `pulumi:"items"`
+	}
+
+	// Version is a version of a bucket.
+	Version struct {
+		ID string `pulumi:"id"`
+	}
+)
+
+// GetBucket looks up a bucket.
+type GetBucket struct{}
+
+type GetBucketArgs struct {
+	// The name of the bucket.
+	Name string `pulumi:"name"`
+}
+
+func (GetBucket) Invoke(
+	ctx context.Context, req infer.FunctionRequest[GetBucketArgs],
+) (infer.FunctionResponse[BucketState], error) {
+	return infer.FunctionResponse[BucketState]{}, nil
+}
+
+// Site is a static website.
+type Site struct {
+	pulumi.ResourceState
+	// The URL of the site.
+	URL pulumi.StringOutput `pulumi:"url"`
+}
+
+type SiteArgs struct {
+	// The index page of the site.
+	Index string `pulumi:"index"`
+}
+
+func NewSite(ctx *pulumi.Context, name string, args SiteArgs, opts ...pulumi.ResourceOption) (*Site, error) {
+	return &Site{}, nil
+}
+
+// Config is the configuration of the provider.
+type Config struct {
+	// The region to create buckets in.
+	Region string `pulumi:"region"`
+}
+
+// Unused is not described, since it is not used by the provider.
+type Unused struct {
+	// A field.
+	Field string `pulumi:"field"`
+}
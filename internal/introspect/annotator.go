@@ -15,8 +15,13 @@
 package introspect
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 )
@@ -27,6 +32,8 @@ func NewAnnotator(resource any) Annotator {
 		Defaults:            map[string]any{},
 		DefaultEnvs:         map[string][]string{},
 		DeprecationMessages: map[string]string{},
+		AutoNames:           map[string]AutoName{},
+		Languages:           map[string]map[string]json.RawMessage{},
 		matcher:             NewFieldMatcher(resource),
 	}
 }
@@ -37,12 +44,43 @@ type Annotator struct {
 	Defaults            map[string]any
 	DefaultEnvs         map[string][]string
 	Token               string
+	GenericFormat       string
 	Aliases             []string
 	DeprecationMessages map[string]string
+	DefaultTimeouts     Timeouts
+	AutoNames           map[string]AutoName
+	// Languages holds the language specific information of each field, keyed by the
+	// field name and then by the language. The information of the struct itself is keyed
+	// by "".
+	Languages map[string]map[string]json.RawMessage
+	Examples  []Example
 
 	matcher FieldMatcher
 }
 
+// Example is a titled set of programs, one per language, that use a resource or a
+// function.
+type Example struct {
+	Title    string
+	Programs map[string]string
+}
+
+// ExampleLanguages are the languages that examples may be written in, in the order that
+// they are shown.
+var ExampleLanguages = []string{"typescript", "python", "go", "csharp", "java", "yaml"}
+
+// AutoName describes how a name field is generated when it is not set.
+type AutoName struct {
+	Pattern   string
+	MaxLength int
+}
+
+// Timeouts holds the default duration of resource operations. A zero value means that the
+// operation has no default timeout.
+type Timeouts struct {
+	Create, Update, Delete time.Duration
+}
+
 func (a *Annotator) mustGetField(i any) FieldTag {
 	field, ok, err := a.matcher.GetField(i)
 	if err != nil {
@@ -94,10 +132,32 @@ func (a *Annotator) SetToken(module tokens.ModuleName, token tokens.TypeName) {
 	a.Token = formatToken(module, token)
 }
 
+func (a *Annotator) SetGenericName(format string) {
+	a.GenericFormat = format
+}
+
 func (a *Annotator) AddAlias(module tokens.ModuleName, token tokens.TypeName) {
 	a.Aliases = append(a.Aliases, formatToken(module, token))
 }
 
+func (a *Annotator) SetDefaultTimeouts(createTimeout, updateTimeout, deleteTimeout time.Duration) {
+	a.DefaultTimeouts = Timeouts{
+		Create: createTimeout,
+		Update: updateTimeout,
+		Delete: deleteTimeout,
+	}
+}
+
+func (a *Annotator) SetAutoName(i any, pattern string, maxLength int) {
+	field := a.mustGetField(i)
+	switch reflect.TypeOf(i).Elem() {
+	case reflect.TypeFor[string](), reflect.TypeFor[*string]():
+	default:
+		panic(fmt.Sprintf("cannot auto-name field %q: auto-named fields must be a string or *string", field.Name))
+	}
+	a.AutoNames[field.Name] = AutoName{Pattern: pattern, MaxLength: maxLength}
+}
+
 func (a *Annotator) Deprecate(i any, message string) {
 	field, ok, err := a.matcher.GetField(i)
 	if err != nil {
@@ -126,6 +186,65 @@ func (a *Annotator) Deprecate(i any, message string) {
 	a.DeprecationMessages[field.Name] = message
 }
 
+func (a *Annotator) SetLanguageName(i any, lang, name string) {
+	if name == "" {
+		panic("language specific names must not be empty")
+	}
+	a.setLanguage(a.mustGetField(i).Name, lang, map[string]any{"name": name})
+}
+
+func (a *Annotator) SetLanguage(i any, lang string, info any) {
+	field, ok, err := a.matcher.GetField(i)
+	if err != nil {
+		panic(fmt.Sprintf("Could not parse field tags: %s", err.Error()))
+	}
+	name := field.Name
+	if !ok {
+		// As with Describe, the struct itself is passed as either *V or **V.
+		typ := reflect.TypeOf(i)
+		if typ.Kind() == reflect.Pointer && typ.Elem().Kind() == reflect.Pointer {
+			i = reflect.ValueOf(i).Elem().Interface()
+		}
+		if a.matcher.value.Addr().Interface() != i {
+			panic("Could not annotate field: could not find field")
+		}
+		name = ""
+	}
+	a.setLanguage(name, lang, info)
+}
+
+// setLanguage sets the info for lang of the field called name, or of the struct itself if
+// name is empty.
+func (a *Annotator) setLanguage(name, lang string, info any) {
+	if lang == "" {
+		panic("language must not be empty")
+	}
+	bytes, err := json.Marshal(info)
+	if err != nil {
+		panic(fmt.Sprintf("could not marshal %s language info: %s", lang, err.Error()))
+	}
+	if a.Languages[name] == nil {
+		a.Languages[name] = map[string]json.RawMessage{}
+	}
+	a.Languages[name][lang] = bytes
+}
+
+func (a *Annotator) AddExample(title string, programs map[string]string) {
+	if title == "" {
+		panic("examples must have a title")
+	}
+	if len(programs) == 0 {
+		panic(fmt.Sprintf("example %q has no programs", title))
+	}
+	for lang := range programs {
+		if !slices.Contains(ExampleLanguages, lang) {
+			panic(fmt.Sprintf("example %q has a program in %q, but examples must be written in one of %s",
+				title, lang, strings.Join(ExampleLanguages, ", ")))
+		}
+	}
+	a.Examples = append(a.Examples, Example{Title: title, Programs: maps.Clone(programs)})
+}
+
 // formatToken formats a (module, token) pair into a valid token string.
 //
 // Panics when module or token are invalid.
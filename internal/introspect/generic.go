@@ -0,0 +1,202 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspect
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// GenericName returns the token name of a type named name, as given by
+// [reflect.Type.Name], and declared in the package pkgPath.
+//
+// The name of an instantiation of a generic type, such as
+// "Page[example.com/pkg.Item]", is its base name followed by "Of" and the names of its
+// type arguments joined by "And", such as "PageOfItem". Type arguments are named in the
+// same way, except that a pointer is named after its element, a slice or an array after
+// its element followed by "Array", a map after its element followed by "Map", and an
+// empty interface "Any". Predeclared types are capitalized, such as "PageOfString".
+//
+// Type arguments declared outside of pkgPath are prefixed with the capitalized name of
+// their package, so that "Page[example.com/other.Item]" is named "PageOfOtherItem" and
+// does not collide with "Page[example.com/pkg.Item]".
+//
+// If format is not empty, an instantiation is instead named fmt.Sprintf(format, args...),
+// where args are the names of its type arguments.
+//
+// Names of types that are not generic are returned as is.
+func GenericName(pkgPath, name, format string) (string, error) {
+	if !strings.Contains(name, "[") {
+		return name, nil
+	}
+	p := typeNameParser{s: name, name: name, pkgPath: pkgPath}
+	base, args, err := p.named(false)
+	if err == nil && p.s != "" {
+		err = p.errorf("unexpected %q", p.s)
+	}
+	if err != nil {
+		return "", err
+	}
+	if format == "" {
+		return genericName(base, args), nil
+	}
+
+	fmtArgs := make([]any, len(args))
+	for i, arg := range args {
+		fmtArgs[i] = arg
+	}
+	formatted := fmt.Sprintf(format, fmtArgs...)
+	if !tokens.IsName(formatted) {
+		return "", fmt.Errorf("name %q of %s must comply with %s, but does not",
+			formatted, name, tokens.NameRegexp)
+	}
+	return formatted, nil
+}
+
+func genericName(base string, args []string) string {
+	if len(args) == 0 {
+		return base
+	}
+	return base + "Of" + strings.Join(args, "And")
+}
+
+// typeNameParser parses the names that package reflect gives to instantiations of generic
+// types, such as "Pair[string,map[string]*example.com/pkg.Item]".
+type typeNameParser struct {
+	s       string // the rest of the name
+	name    string // the whole name, for errors
+	pkgPath string // the package that declares the generic type
+}
+
+func (p *typeNameParser) errorf(format string, a ...any) error {
+	return fmt.Errorf("invalid generic type name %q: %s", p.name, fmt.Sprintf(format, a...))
+}
+
+func (p *typeNameParser) consume(prefix string) bool {
+	if strings.HasPrefix(p.s, prefix) {
+		p.s = p.s[len(prefix):]
+		return true
+	}
+	return false
+}
+
+// named parses a possibly qualified type name and its type arguments, returning the
+// unqualified name and the names of the type arguments. If qualify is true, a name from a
+// package other than p.pkgPath is prefixed with the name of its package.
+func (p *typeNameParser) named(qualify bool) (string, []string, error) {
+	end := strings.IndexAny(p.s, "[],")
+	if end < 0 {
+		end = len(p.s)
+	}
+	qualified := p.s[:end]
+	p.s = p.s[end:]
+	if qualified == "" {
+		return "", nil, p.errorf("missing type name")
+	}
+	// Remove the package path, which may itself contain dots.
+	var pkgPath string
+	base := qualified
+	if i := strings.LastIndex(base, "."); i >= 0 && strings.LastIndex(base, "/") < i {
+		pkgPath, base = base[:i], base[i+1:]
+	}
+	base = capitalize(base)
+	if qualify && pkgPath != "" && pkgPath != p.pkgPath {
+		base = packageName(pkgPath) + base
+	}
+
+	if !p.consume("[") {
+		return base, nil, nil
+	}
+	var args []string
+	for {
+		arg, err := p.typeArg()
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, arg)
+		if p.consume("]") {
+			return base, args, nil
+		}
+		if !p.consume(",") {
+			return "", nil, p.errorf("expected \",\" or \"]\"")
+		}
+	}
+}
+
+// typeArg parses a type argument and returns its name.
+func (p *typeNameParser) typeArg() (string, error) {
+	switch {
+	case p.consume("*"):
+		return p.typeArg()
+	case p.consume("[]"):
+		elem, err := p.typeArg()
+		return elem + "Array", err
+	case p.consume("map["):
+		if _, err := p.typeArg(); err != nil {
+			return "", err
+		}
+		if !p.consume("]") {
+			return "", p.errorf("expected \"]\"")
+		}
+		elem, err := p.typeArg()
+		return elem + "Map", err
+	case p.consume("interface {}"):
+		return "Any", nil
+	case p.consume("["):
+		// An array, such as [4]T.
+		end := strings.Index(p.s, "]")
+		if end < 0 {
+			return "", p.errorf("expected \"]\"")
+		}
+		p.s = p.s[end+1:]
+		elem, err := p.typeArg()
+		return elem + "Array", err
+	}
+	if strings.HasPrefix(p.s, "func(") || strings.HasPrefix(p.s, "chan ") ||
+		strings.HasPrefix(p.s, "struct {") || strings.HasPrefix(p.s, "interface {") {
+		return "", p.errorf("type arguments must be named types, pointers, slices, arrays or maps")
+	}
+	base, args, err := p.named(true)
+	return genericName(base, args), err
+}
+
+// packageName returns a capitalized name for the package at pkgPath, derived from the
+// last element of the path, such as "Yaml" for "gopkg.in/yaml.v3" and "GoProvider" for
+// "example.com/go-provider".
+func packageName(pkgPath string) string {
+	elem := pkgPath[strings.LastIndex(pkgPath, "/")+1:]
+	if i := strings.Index(elem, "."); i >= 0 {
+		elem = elem[:i]
+	}
+	var name strings.Builder
+	for _, word := range strings.FieldsFunc(elem, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		name.WriteString(capitalize(word))
+	}
+	return name.String()
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
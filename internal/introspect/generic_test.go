@@ -0,0 +1,118 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspect_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+
+	"github.com/pulumi/pulumi-go-provider/internal/introspect"
+)
+
+type (
+	Page[T any]    struct{}
+	Pair[K, V any] struct{}
+	item           struct{}
+)
+
+func TestGenericName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		typ      reflect.Type
+		expected string
+	}{
+		{reflect.TypeFor[MyStruct](), "MyStruct"},
+		{reflect.TypeFor[Page[MyStruct]](), "PageOfMyStruct"},
+		{reflect.TypeFor[Page[item]](), "PageOfItem"},
+		{reflect.TypeFor[Page[string]](), "PageOfString"},
+		{reflect.TypeFor[Page[*MyStruct]](), "PageOfMyStruct"},
+		{reflect.TypeFor[Page[[]MyStruct]](), "PageOfMyStructArray"},
+		{reflect.TypeFor[Page[[2]int]](), "PageOfIntArray"},
+		{reflect.TypeFor[Page[map[string]*MyStruct]](), "PageOfMyStructMap"},
+		{reflect.TypeFor[Page[any]](), "PageOfAny"},
+		{reflect.TypeFor[Page[tokens.Type]](), "PageOfTokensType"},
+		{reflect.TypeFor[Page[Page[MyStruct]]](), "PageOfPageOfMyStruct"},
+		{reflect.TypeFor[Pair[string, Page[item]]](), "PairOfStringAndPageOfItem"},
+		{reflect.TypeFor[Pair[map[string][]int, float64]](), "PairOfIntArrayMapAndFloat64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			t.Parallel()
+
+			name, err := introspect.GenericName(tt.typ.PkgPath(), tt.typ.Name(), "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, name)
+		})
+	}
+}
+
+func TestGenericNameFormat(t *testing.T) {
+	t.Parallel()
+
+	typ := reflect.TypeFor[Pair[string, item]]()
+	name, err := introspect.GenericName(typ.PkgPath(), typ.Name(), "%[2]sBy%[1]s")
+	require.NoError(t, err)
+	assert.Equal(t, "ItemByString", name)
+
+	typ = reflect.TypeFor[Page[item]]()
+	_, err = introspect.GenericName(typ.PkgPath(), typ.Name(), "%s Page")
+	assert.ErrorContains(t, err, `name "Item Page" of`)
+
+	typ = reflect.TypeFor[Page[func()]]()
+	_, err = introspect.GenericName(typ.PkgPath(), typ.Name(), "")
+	assert.EqualError(t, err, `invalid generic type name "Page[func()]": `+
+		`type arguments must be named types, pointers, slices, arrays or maps`)
+}
+
+func TestGenericNameOtherPackages(t *testing.T) {
+	t.Parallel()
+
+	// tokens.Type and reflect.Type share a name, so they must be told apart by their
+	// packages.
+	tk1, err := introspect.GetToken("pkg", reflect.TypeFor[Page[tokens.Type]]())
+	require.NoError(t, err)
+	assert.Equal(t, tokens.Type("pkg:introspect_test:PageOfTokensType"), tk1)
+
+	tk2, err := introspect.GetToken("pkg", reflect.TypeFor[Page[reflect.Type]]())
+	require.NoError(t, err)
+	assert.Equal(t, tokens.Type("pkg:introspect_test:PageOfReflectType"), tk2)
+
+	// Arguments from the package of the generic type itself are not qualified.
+	tk, err := introspect.GetToken("pkg", reflect.TypeFor[Pair[item, tokens.Type]]())
+	require.NoError(t, err)
+	assert.Equal(t, tokens.Type("pkg:introspect_test:PairOfItemAndTokensType"), tk)
+
+	tk, err = introspect.GetGenericToken("pkg", reflect.TypeFor[Page[reflect.Type]](), "%sPage")
+	require.NoError(t, err)
+	assert.Equal(t, tokens.Type("pkg:introspect_test:ReflectTypePage"), tk)
+}
+
+func TestGetGenericToken(t *testing.T) {
+	t.Parallel()
+
+	tk, err := introspect.GetToken("pkg", reflect.TypeFor[*Page[MyStruct]]())
+	require.NoError(t, err)
+	assert.Equal(t, tokens.Type("pkg:introspect_test:PageOfMyStruct"), tk)
+
+	tk, err = introspect.GetGenericToken("pkg", reflect.TypeFor[Page[MyStruct]](), "%sPage")
+	require.NoError(t, err)
+	assert.Equal(t, tokens.Type("pkg:introspect_test:MyStructPage"), tk)
+}
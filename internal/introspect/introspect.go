@@ -80,7 +80,15 @@ func FindProperties(typ reflect.Type) (map[string]FieldTag, error) {
 }
 
 // GetToken calculates the Pulumi token that typ would be projected into.
+//
+// Instantiations of generic types are named by [GenericName].
 func GetToken(pkg tokens.Package, typ reflect.Type) (tokens.Type, error) {
+	return GetGenericToken(pkg, typ, "")
+}
+
+// GetGenericToken is like [GetToken], except that instantiations of generic types are
+// named by format, as described by [GenericName].
+func GetGenericToken(pkg tokens.Package, typ reflect.Type, format string) (tokens.Type, error) {
 	if typ == nil {
 		return "", fmt.Errorf("cannot get token of nil type")
 	}
@@ -98,6 +106,10 @@ func GetToken(pkg tokens.Package, typ reflect.Type) (tokens.Type, error) {
 	if mod == "" {
 		return "", fmt.Errorf("type %s has no module path", typ)
 	}
+	name, err := GenericName(typ.PkgPath(), name, format)
+	if err != nil {
+		return "", err
+	}
 	// Take off the pkg name, since that is supplied by `pkg`.
 	mod = mod[strings.LastIndex(mod, "/")+1:]
 	if mod == "main" {
@@ -128,6 +140,7 @@ func ParseTag(field reflect.StructField) (FieldTag, error) {
 	}
 
 	var explRef *ExplicitType
+	var aliases []string
 	provider := map[string]bool{}
 	providerArray := strings.Split(providerTag, ",")
 	if hasProviderTag {
@@ -162,6 +175,13 @@ func ParseTag(field reflect.StructField) (FieldTag, error) {
 				}
 				continue
 			}
+			if alias, ok := strings.CutPrefix(item, "alias="); ok {
+				if alias == "" || alias == name {
+					return FieldTag{}, fmt.Errorf(`"alias=" must name a property other than %q`, name)
+				}
+				aliases = append(aliases, alias)
+				continue
+			}
 			provider[item] = true
 		}
 	}
@@ -181,6 +201,7 @@ func ParseTag(field reflect.StructField) (FieldTag, error) {
 		Secret:           provider["secret"],
 		ReplaceOnChanges: provider["replaceOnChanges"],
 		ExplicitRef:      explRef,
+		Aliases:          aliases,
 	}, nil
 }
 
@@ -198,6 +219,7 @@ type FieldTag struct {
 	Internal    bool          // If the field should exist in the Pulumi type system.
 	Secret      bool          // If the field is secret.
 	ExplicitRef *ExplicitType // The name and version of the external type consumed in the field.
+	Aliases     []string      // Former names of the field, which are accepted when decoding.
 	// NOTE: ReplaceOnChanges will only be obeyed when the default diff implementation is used.
 	ReplaceOnChanges bool // If changes in the field should force a replacement.
 }
@@ -15,6 +15,7 @@
 package introspect_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
@@ -34,6 +35,8 @@ type MyStruct struct {
 	Fizz        *int   `pulumi:"fizz"`
 	ExtType     string `pulumi:"typ" provider:"type=example@1.2.3:m1:m2"`
 	WrongSecret string `pulumi:"wrongSecret,secret"`
+	Renamed     string `pulumi:"name" provider:"alias=bucketName,alias=oldName"`
+	SelfAlias   string `pulumi:"self" provider:"alias=self"`
 }
 
 func (m *MyStruct) Annotate(a infer.Annotator) {
@@ -84,6 +87,17 @@ func TestParseTag(t *testing.T) {
 				},
 			},
 		},
+		{
+			Field: "Renamed",
+			Expected: introspect.FieldTag{
+				Name:    "name",
+				Aliases: []string{"bucketName", "oldName"},
+			},
+		},
+		{
+			Field: "SelfAlias",
+			Error: `"alias=" must name a property other than "self"`,
+		},
 		{
 			Field: "WrongSecret",
 			Error: "`marking a field as secret in the `pulumi` tag namespace is not allowed, use `provider` instead",
@@ -123,6 +137,64 @@ func TestAnnotate(t *testing.T) {
 	assert.Equal(t, []string{"pkg:myMod:MyAlias"}, a.Aliases)
 }
 
+func TestSetAutoName(t *testing.T) {
+	t.Parallel()
+
+	s := &MyStruct{}
+	a := introspect.NewAnnotator(s)
+
+	a.SetAutoName(&s.Renamed, "${name}-${hex(4)}", 32)
+	assert.Equal(t, map[string]introspect.AutoName{
+		"name": {Pattern: "${name}-${hex(4)}", MaxLength: 32},
+	}, a.AutoNames)
+
+	assert.Panics(t, func() { a.SetAutoName(&s.Fizz, "", 0) })
+}
+
+func TestSetLanguage(t *testing.T) {
+	t.Parallel()
+
+	s := &MyStruct{}
+	a := introspect.NewAnnotator(s)
+
+	a.SetLanguageName(&s.Renamed, "python", "name_")
+	a.SetLanguage(&s.Renamed, "csharp", map[string]any{"name": "BucketName"})
+	a.SetLanguage(&s, "nodejs", map[string]any{"requiredInputs": []string{"fizz"}})
+	assert.Equal(t, map[string]map[string]json.RawMessage{
+		"name": {
+			"python": json.RawMessage(`{"name":"name_"}`),
+			"csharp": json.RawMessage(`{"name":"BucketName"}`),
+		},
+		"": {
+			"nodejs": json.RawMessage(`{"requiredInputs":["fizz"]}`),
+		},
+	}, a.Languages)
+
+	assert.Panics(t, func() { a.SetLanguageName(&s, "python", "struct") })
+	assert.Panics(t, func() { a.SetLanguageName(&s.Renamed, "python", "") })
+	assert.Panics(t, func() { a.SetLanguage(&s.Renamed, "", nil) })
+	assert.Panics(t, func() { a.SetLanguage(&s.Renamed, "go", func() {}) })
+}
+
+func TestAddExample(t *testing.T) {
+	t.Parallel()
+
+	s := &MyStruct{}
+	a := introspect.NewAnnotator(s)
+
+	programs := map[string]string{"typescript": "new Foo();", "yaml": "resources: {}"}
+	a.AddExample("Basic", programs)
+	programs["go"] = "NewFoo()"
+	assert.Equal(t, []introspect.Example{{
+		Title:    "Basic",
+		Programs: map[string]string{"typescript": "new Foo();", "yaml": "resources: {}"},
+	}}, a.Examples)
+
+	assert.Panics(t, func() { a.AddExample("", programs) })
+	assert.Panics(t, func() { a.AddExample("No programs", nil) })
+	assert.Panics(t, func() { a.AddExample("Unknown language", map[string]string{"cobol": "."}) })
+}
+
 func TestSetTokenValidation(t *testing.T) {
 	t.Parallel()
 
@@ -43,6 +43,8 @@ func Wrap(provider p.Provider, wrapper Wrapper) p.Provider {
 		Delete:      delegateI(wrapper, provider.Delete),
 		Construct:   delegateIO(wrapper, provider.Construct),
 		Call:        delegateIO(wrapper, provider.Call),
+
+		SupportsAutonamingConfiguration: provider.SupportsAutonamingConfiguration,
 	}
 }
 
@@ -141,11 +141,20 @@ func Provider(server rpc.ResourceProviderServer) p.Provider {
 				return p.CheckResponse{}, err
 			}
 
+			var autonaming *rpc.CheckRequest_AutonamingOptions
+			if a := req.Autonaming; a != nil {
+				autonaming = &rpc.CheckRequest_AutonamingOptions{
+					ProposedName: a.ProposedName,
+					Mode:         rpc.CheckRequest_AutonamingOptions_Mode(a.Mode),
+				}
+			}
+
 			return checkResponse(server.Check(ctx, &rpc.CheckRequest{
 				Urn:        string(req.Urn),
 				Olds:       olds,
 				News:       news,
 				RandomSeed: req.RandomSeed,
+				Autonaming: autonaming,
 			}))
 		},
 		Diff: func(ctx context.Context, req p.DiffRequest) (p.DiffResponse, error) {
@@ -0,0 +1,174 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	examplesSection = regexp.MustCompile(`(?s){{% examples %}}(.*?){{% /examples %}}`)
+	yamlProgram     = regexp.MustCompile("(?s)```yaml\n(.*?)```")
+)
+
+// validateExamples checks the YAML programs in the examples of the resources and functions
+// of pkg. Each resource and function of pkg that a program uses must only be given inputs
+// that it has.
+//
+// Programs in other languages are not checked, since they cannot be parsed without the
+// SDK of pkg.
+func validateExamples(pkg schema.PackageSpec) multierror.Error {
+	var errs multierror.Error
+	check := func(kind, tk, description string) {
+		for _, section := range examplesSection.FindAllStringSubmatch(description, -1) {
+			for _, program := range yamlProgram.FindAllStringSubmatch(section[1], -1) {
+				for _, err := range validateYAMLProgram(pkg, program[1]) {
+					errs.Errors = append(errs.Errors,
+						fmt.Errorf("invalid example for %s '%s': %w", kind, tk, err))
+				}
+			}
+		}
+	}
+	for _, tk := range slices.Sorted(maps.Keys(pkg.Resources)) {
+		check("resource", tk, pkg.Resources[tk].Description)
+	}
+	for _, tk := range slices.Sorted(maps.Keys(pkg.Functions)) {
+		check("function", tk, pkg.Functions[tk].Description)
+	}
+	return errs
+}
+
+// validateYAMLProgram checks the uses of the resources and functions of pkg in a Pulumi
+// YAML program.
+func validateYAMLProgram(pkg schema.PackageSpec, program string) []error {
+	var doc struct {
+		Resources map[string]struct {
+			Type       string         `yaml:"type"`
+			Properties map[string]any `yaml:"properties"`
+		} `yaml:"resources"`
+	}
+	var root any
+	if err := yaml.Unmarshal([]byte(program), &doc); err != nil {
+		return []error{err}
+	}
+	if err := yaml.Unmarshal([]byte(program), &root); err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	ours := func(tk string) bool { return strings.HasPrefix(tk, pkg.Name+":") }
+
+	for _, name := range slices.Sorted(maps.Keys(doc.Resources)) {
+		r := doc.Resources[name]
+		if !ours(r.Type) {
+			continue
+		}
+		tk, spec, ok := lookupToken(pkg.Resources, r.Type)
+		if !ok {
+			errs = append(errs, fmt.Errorf("resource %q has unknown type '%s'", name, r.Type))
+			continue
+		}
+		for _, prop := range slices.Sorted(maps.Keys(r.Properties)) {
+			if _, ok := spec.InputProperties[prop]; !ok {
+				errs = append(errs, fmt.Errorf("resource %q sets '%s', which is not an input of '%s'",
+					name, prop, tk))
+			}
+		}
+	}
+
+	// Invokes may appear in any expression, so we look for them everywhere.
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			if invoke, ok := v["fn::invoke"].(map[string]any); ok {
+				errs = append(errs, validateInvoke(pkg, invoke, ours)...)
+			}
+			for _, k := range slices.Sorted(maps.Keys(v)) {
+				walk(v[k])
+			}
+		case []any:
+			for _, e := range v {
+				walk(e)
+			}
+		}
+	}
+	walk(root)
+	return errs
+}
+
+func validateInvoke(pkg schema.PackageSpec, invoke map[string]any, ours func(string) bool) []error {
+	fn, _ := invoke["function"].(string)
+	if !ours(fn) {
+		return nil
+	}
+	tk, spec, ok := lookupToken(pkg.Functions, fn)
+	if !ok {
+		return []error{fmt.Errorf("unknown function '%s'", fn)}
+	}
+	args, _ := invoke["arguments"].(map[string]any)
+	var errs []error
+	for _, arg := range slices.Sorted(maps.Keys(args)) {
+		if spec.Inputs == nil {
+			errs = append(errs, fmt.Errorf("function '%s' takes no arguments, but is given '%s'", tk, arg))
+			continue
+		}
+		if _, ok := spec.Inputs.Properties[arg]; !ok {
+			errs = append(errs, fmt.Errorf("'%s' is not an argument of function '%s'", arg, tk))
+		}
+	}
+	return errs
+}
+
+// lookupToken finds the element of m that tk refers to in a Pulumi YAML program, returning
+// its token. As in Pulumi YAML, tk may omit the "index" module, as in "pkg:Name", and
+// may be written with or without the name of the element in the module, as in
+// "pkg:mod/name:Name".
+func lookupToken[T any](m map[string]T, tk string) (string, T, bool) {
+	if v, ok := m[tk]; ok {
+		return tk, v, true
+	}
+	var pkg, mod, name string
+	switch parts := strings.Split(tk, ":"); len(parts) {
+	case 2:
+		pkg, mod, name = parts[0], "index", parts[1]
+	case 3:
+		pkg, mod, name = parts[0], parts[1], parts[2]
+	default:
+		var zero T
+		return "", zero, false
+	}
+	mod, _, _ = strings.Cut(mod, "/")
+	candidates := []string{pkg + ":" + mod + ":" + name}
+	if name != "" {
+		lower := strings.ToLower(name[:1]) + name[1:]
+		candidates = append(candidates, pkg+":"+mod+"/"+lower+":"+name)
+	}
+	for _, candidate := range candidates {
+		if v, ok := m[candidate]; ok {
+			return candidate, v, true
+		}
+	}
+	var zero T
+	return "", zero, false
+}
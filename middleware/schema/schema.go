@@ -337,6 +337,8 @@ func (s *state) generateSchema(ctx context.Context) (schema.PackageSpec, error)
 			Required:  prov.RequiredInputs,
 		}
 	}
+	e = validateExamples(pkg)
+	errs.Errors = append(errs.Errors, e.Errors...)
 	if err := errs.ErrorOrNil(); err != nil {
 		return schema.PackageSpec{}, err
 	}
@@ -423,6 +425,14 @@ func renamePackage[T any](typ T, pkg string, modMap map[tokens.ModuleName]tokens
 				rewritten := fixReference(field.String(), pkg, modMap)
 				field.SetString(rewritten)
 			}
+			if v.Type() == reflect.TypeOf(schema.DiscriminatorSpec{}) {
+				// The mapping holds references as plain strings.
+				mapping := v.FieldByName("Mapping")
+				for iter := mapping.MapRange(); iter.Next(); {
+					rewritten := fixReference(iter.Value().String(), pkg, modMap)
+					mapping.SetMapIndex(iter.Key(), reflect.ValueOf(rewritten))
+				}
+			}
 			if v.Type() == reflect.TypeOf(schema.AliasSpec{}) {
 				field := v.FieldByName("Type")
 				tk, err := tokens.ParseTypeToken(field.String())
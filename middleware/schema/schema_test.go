@@ -60,3 +60,108 @@ func TestRenamePackage(t *testing.T) {
 	arr = renamePackage(arr, "buzz", map[tokens.ModuleName]tokens.ModuleName{})
 	assert.Equal(t, "#/resources/buzz:fizz:Buzz", arr[1].Ref)
 }
+
+func TestValidateExamples(t *testing.T) {
+	t.Parallel()
+
+	example := func(program string) string {
+		return "A bucket.\n\n{{% examples %}}\n## Example Usage\n{{% example %}}\n### Basic\n\n" +
+			"```yaml\n" + program + "\n```\n{{% /example %}}\n{{% /examples %}}"
+	}
+	pkg := schema.PackageSpec{
+		Name: "test",
+		Resources: map[string]schema.ResourceSpec{
+			"test:index:Bucket": {
+				ObjectTypeSpec: schema.ObjectTypeSpec{
+					Description: example(`resources:
+  b:
+    type: test:index:Bucket
+    properties:
+      acl: private
+      aclz: public
+  other:
+    type: other:index:Thing
+    properties:
+      anything: 1
+  missing:
+    type: test:index:Missing
+  short:
+    type: test:Bucket
+    properties:
+      acl: private
+      size: 1
+  long:
+    type: test:index/bucket:Bucket
+    properties:
+      acl: private
+variables:
+  found:
+    fn::invoke:
+      function: test:index:getBucket
+      arguments:
+        name: b
+        nmae: b
+  short:
+    fn::invoke:
+      function: test:getBucket
+      arguments:
+        name: b
+        size: 1`),
+				},
+				InputProperties: map[string]schema.PropertySpec{"acl": {}},
+			},
+		},
+		Functions: map[string]schema.FunctionSpec{
+			"test:index:getBucket": {
+				Description: example("invalid: [yaml"),
+				Inputs: &schema.ObjectTypeSpec{
+					Properties: map[string]schema.PropertySpec{"name": {}},
+				},
+			},
+			// Only YAML programs are checked, so an example in another language is not,
+			// however wrong it is.
+			"test:index:listBuckets": {
+				Description: "{{% examples %}}\n## Example Usage\n{{% example %}}\n### Basic\n\n" +
+					"```typescript\nconst b = test.missing({ nmae: 1 });\n```\n{{% /example %}}\n{{% /examples %}}",
+			},
+		},
+	}
+
+	errs := validateExamples(pkg)
+	msgs := make([]string, len(errs.Errors))
+	for i, err := range errs.Errors {
+		msgs[i] = err.Error()
+	}
+	assert.Equal(t, []string{
+		`invalid example for resource 'test:index:Bucket': resource "b" sets 'aclz', ` +
+			`which is not an input of 'test:index:Bucket'`,
+		`invalid example for resource 'test:index:Bucket': resource "missing" has unknown type 'test:index:Missing'`,
+		`invalid example for resource 'test:index:Bucket': resource "short" sets 'size', ` +
+			`which is not an input of 'test:index:Bucket'`,
+		`invalid example for resource 'test:index:Bucket': 'nmae' is not an argument of function 'test:index:getBucket'`,
+		`invalid example for resource 'test:index:Bucket': 'size' is not an argument of function 'test:index:getBucket'`,
+		"invalid example for function 'test:index:getBucket': yaml: line 1: did not find expected ',' or ']'",
+	}, msgs)
+}
+
+func TestLookupToken(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]int{
+		"test:index:Bucket":          1,
+		"test:storage/object:Object": 2,
+	}
+	for tk, expected := range map[string]string{
+		"test:Bucket":                "test:index:Bucket",
+		"test:index:Bucket":          "test:index:Bucket",
+		"test:index/bucket:Bucket":   "test:index:Bucket",
+		"test:storage:Object":        "test:storage/object:Object",
+		"test:storage/object:Object": "test:storage/object:Object",
+		"test:Object":                "",
+		"test":                       "",
+	} {
+		actual, _, ok := lookupToken(m, tk)
+		assert.Equal(t, expected != "", ok, tk)
+		assert.Equal(t, expected, actual, tk)
+	}
+}
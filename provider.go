@@ -66,8 +66,31 @@ type CheckRequest struct {
 	State      property.Map
 	Inputs     property.Map
 	RandomSeed []byte
+	// Autonaming is the autonaming configuration of the stack, or nil if the stack does
+	// not configure autonaming.
+	Autonaming *AutonamingOptions
 }
 
+// AutonamingOptions describes how the engine wants a resource to be named.
+type AutonamingOptions struct {
+	// ProposedName is the name the engine generated for the resource.
+	ProposedName string
+	// Mode describes how the provider should use ProposedName.
+	Mode AutonamingMode
+}
+
+type AutonamingMode int32
+
+const (
+	// AutonamingModePropose means that the provider should use ProposedName, unless
+	// the name would not be valid for the resource.
+	AutonamingModePropose AutonamingMode = iota
+	// AutonamingModeEnforce means that the provider must use ProposedName as is.
+	AutonamingModeEnforce
+	// AutonamingModeDisable means that the provider must not generate a name.
+	AutonamingModeDisable
+)
+
 type CheckFailure struct {
 	Property string
 	Reason   string
@@ -344,6 +367,11 @@ type Provider struct {
 	DiffConfig  func(context.Context, DiffRequest) (DiffResponse, error)
 	// NOTE: We opt into all options.
 	Configure func(context.Context, ConfigureRequest) error
+	// SupportsAutonamingConfiguration indicates that Check understands
+	// [CheckRequest.Autonaming].
+	//
+	// The engine only sends the stack's autonaming configuration to providers that opt in.
+	SupportsAutonamingConfiguration bool
 
 	// Invokes
 	Invoke func(context.Context, InvokeRequest) (InvokeResponse, error)
@@ -473,13 +501,24 @@ func (d Provider) Run(ctx context.Context, name string, version string) error {
 }
 
 // RunProvider runs a provider with the given name and version.
+//
+// When the binary is run with the docs subcommand, the provider is not served. Instead,
+// reference documentation is rendered from its schema with [docs.Write]:
+//
+//	./pulumi-resource-mypkg docs -format html -out ./reference
 func RunProvider(ctx context.Context, name, version string, provider Provider) error {
+	if len(os.Args) > 1 && os.Args[1] == docsCommand {
+		return runDocs(ctx, name, version, provider, os.Args[2:])
+	}
 	return RunProviderF(ctx, name, version, func(_ *pprovider.HostClient) (Provider, error) {
 		return provider, nil
 	})
 }
 
 // RunProviderF allows running a provider that has not yet been bound to a HostClient.
+//
+// Unlike [RunProvider], RunProviderF does not handle the docs subcommand, since providerF
+// cannot build the provider without a host.
 func RunProviderF(
 	ctx context.Context,
 	name string,
@@ -550,10 +589,9 @@ func (e *errCollectingContext) RuntimeInformation() RunInfo {
 //
 //	pulumi package get-schema ./pulumi-resource-MYPROVIDER
 func GetSchema(ctx context.Context, name, version string, provider Provider) (schema.PackageSpec, error) {
-	collectingDiag := errCollectingContext{Context: ctx, stderr: os.Stderr, info: RunInfo{
-		PackageName: name,
-		Version:     version,
-	}}
+	info := RunInfo{PackageName: name, Version: version}
+	ctx = context.WithValue(ctx, key.RuntimeInfo, info)
+	collectingDiag := errCollectingContext{Context: ctx, stderr: os.Stderr, info: info}
 	s, err := provider.GetSchema(&collectingDiag, GetSchemaRequest{Version: 0})
 	var errs multierror.Error
 	if err != nil {
@@ -764,10 +802,11 @@ func (p *provider) Configure(ctx context.Context, req *rpc.ConfigureRequest) (*r
 		return nil, err
 	}
 	return &rpc.ConfigureResponse{
-		AcceptSecrets:   true,
-		SupportsPreview: true,
-		AcceptResources: true,
-		AcceptOutputs:   true,
+		AcceptSecrets:                   true,
+		SupportsPreview:                 true,
+		AcceptResources:                 true,
+		AcceptOutputs:                   true,
+		SupportsAutonamingConfiguration: p.client.SupportsAutonamingConfiguration,
 	}, nil
 }
 
@@ -994,10 +1033,20 @@ func (p *provider) Check(ctx context.Context, req *rpc.CheckRequest) (*rpc.Check
 		return nil, err
 	}
 
+	var autonaming *AutonamingOptions
+	if a := req.GetAutonaming(); a != nil {
+		autonaming = &AutonamingOptions{
+			ProposedName: a.GetProposedName(),
+			Mode:         AutonamingMode(a.GetMode()),
+		}
+	}
+
 	r, err := p.client.Check(ctx, CheckRequest{
-		Urn:    presource.URN(req.GetUrn()),
-		State:  olds,
-		Inputs: news,
+		Urn:        presource.URN(req.GetUrn()),
+		State:      olds,
+		Inputs:     news,
+		RandomSeed: req.RandomSeed,
+		Autonaming: autonaming,
 	})
 	if err != nil {
 		return nil, err
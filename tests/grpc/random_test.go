@@ -0,0 +1,74 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	replay "github.com/pulumi/providertest/replay"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+// TestCheckRandomSeed asserts that the random seed sent by the engine reaches
+// [infer.CustomCheck].
+func TestCheckRandomSeed(t *testing.T) {
+	s, err := p.RawServer("random", "v0.1.0",
+		infer.Provider(infer.Options{
+			Resources: []infer.InferredResource{infer.Resource(&Login{})},
+		}))(nil)
+	require.NoError(t, err)
+
+	replay.Replay(t, s, `{
+    "method": "/pulumirpc.ResourceProvider/Check",
+    "request": {
+        "urn": "urn:pulumi:dev::random::random:grpc:Login::login",
+        "olds": {},
+        "news": {},
+        "randomSeed": "DX1REXFaeMHkgqCyRyC0As5/kNtfiZT5jQv1AdX4T8Y="
+    },
+    "response": {
+        "inputs": {
+            "password": {
+                "4dabf18193072939515e22adb298388d": "1b47061264138c4ac30d75fd1eb44270",
+                "value": "{<8*:&E{a=%_"
+            }
+        }
+    }
+}`)
+}
+
+type Login struct{}
+
+type LoginArgs struct {
+	Password string `pulumi:"password,optional" provider:"secret"`
+}
+
+func (*Login) Check(ctx context.Context, req infer.CheckRequest) (infer.CheckResponse[LoginArgs], error) {
+	args, failures, err := infer.DefaultCheck[LoginArgs](ctx, req.NewInputs)
+	if args.Password == "" {
+		args.Password = req.Random("password").Password(12)
+	}
+	return infer.CheckResponse[LoginArgs]{Inputs: args, Failures: failures}, err
+}
+
+func (*Login) Create(
+	context.Context, infer.CreateRequest[LoginArgs],
+) (infer.CreateResponse[LoginArgs], error) {
+	panic("THE CURRENT TEST ONLY TESTS 'CHECK'")
+}
@@ -16,6 +16,7 @@ package tests
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -98,3 +99,19 @@ func TestMergeSchema(t *testing.T) {
     }
 }`, bytes.String())
 }
+
+func TestGetSchemaRunInfo(t *testing.T) {
+	t.Parallel()
+
+	var info p.RunInfo
+	spec, err := p.GetSchema(t.Context(), "pkg", "1.2.3", p.Provider{
+		GetSchema: func(ctx context.Context, _ p.GetSchemaRequest) (p.GetSchemaResponse, error) {
+			info = p.GetRunInfo(ctx)
+			return p.GetSchemaResponse{Schema: `{"name":"pkg"}`}, nil
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "pkg", spec.Name)
+	assert.Equal(t, p.RunInfo{PackageName: "pkg", Version: "1.2.3"}, info)
+}